@@ -0,0 +1,219 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "math"
+
+// darc is an arc used by Chu-Liu/Edmonds algorithm, id refers to the arc of the upper level (or the origin graph).
+type darc struct {
+	from, to int
+	weight   float64
+	id       int
+}
+
+// Chu-Liu/Edmonds Algorithm:
+//
+// 1. For every vertex v except root, choose the incoming arc of v with minimum weight.
+// 2. If the chosen arcs contain no cycle, they form the minimum arborescence.
+// 3. Otherwise contract every cycle C into a single vertex, and for every arc (u,v) entering C
+// replace its weight by w(u,v) - w(in(v)), where in(v) is the chosen arc of v.
+// 4. Find the minimum arborescence of the contracted graph recursively, then expand every cycle:
+// keep all arcs of the cycle except the chosen arc of the vertex which is entered by the arborescence.
+//
+// Return the ids of arcs of the arborescence, or false if some vertex is unreachable from root.
+func edmonds(n, root int, arcs []darc) ([]int, bool) {
+	in := make([]int, n)
+	for i := range in {
+		in[i] = -1
+	}
+	for i, a := range arcs {
+		if a.from == a.to || a.to == root {
+			continue
+		}
+		if in[a.to] == -1 || a.weight < arcs[in[a.to]].weight {
+			in[a.to] = i
+		}
+	}
+	for v := 0; v < n; v++ {
+		if v != root && in[v] == -1 {
+			return nil, false
+		}
+	}
+	// find cycles of the chosen arcs.
+	comp := make([]int, n)
+	visit := make([]int, n)
+	for i := range comp {
+		comp[i], visit[i] = -1, -1
+	}
+	var cycles int
+	for v := 0; v < n; v++ {
+		x := v
+		for x != root && comp[x] == -1 && visit[x] != v {
+			visit[x] = v
+			x = arcs[in[x]].from
+		}
+		if x != root && comp[x] == -1 {
+			for y := arcs[in[x]].from; y != x; y = arcs[in[y]].from {
+				comp[y] = cycles
+			}
+			comp[x] = cycles
+			cycles++
+		}
+	}
+	if cycles == 0 {
+		var res []int
+		for v := 0; v < n; v++ {
+			if v != root {
+				res = append(res, arcs[in[v]].id)
+			}
+		}
+		return res, true
+	}
+	m := cycles
+	for v := 0; v < n; v++ {
+		if comp[v] == -1 {
+			comp[v] = m
+			m++
+		}
+	}
+	var contracted []darc
+	for i, a := range arcs {
+		cu, cv := comp[a.from], comp[a.to]
+		if cu == cv {
+			continue
+		}
+		w := a.weight
+		if cv < cycles {
+			w -= arcs[in[a.to]].weight
+		}
+		contracted = append(contracted, darc{from: cu, to: cv, weight: w, id: i})
+	}
+	sel, ok := edmonds(m, comp[root], contracted)
+	if !ok {
+		return nil, false
+	}
+	entered := make([]bool, n)
+	var res []int
+	for _, i := range sel {
+		res = append(res, arcs[i].id)
+		if comp[arcs[i].to] < cycles {
+			entered[arcs[i].to] = true
+		}
+	}
+	for v := 0; v < n; v++ {
+		if comp[v] < cycles && !entered[v] {
+			res = append(res, arcs[in[v]].id)
+		}
+	}
+	return res, true
+}
+
+func arborescenceForest[K comparable, W number](g Digraph[K, W], ig *indexedGraph[K, W], root int, sel []int) (*Forest[K, W], W, error) {
+	var w W
+	f := NewForest[K, W]()
+	for _, v := range g.AllVertexes() {
+		if err := f.AddVertex(v); err != nil {
+			return nil, w, err
+		}
+	}
+	for _, i := range sel {
+		if err := f.AddEdge(ig.edges[i]); err != nil {
+			return nil, w, err
+		}
+		w += ig.edges[i].Weight
+	}
+	f.SetRoot(ig.keys[root])
+	f.SetDirected(ig.keys[root])
+	return f, w, nil
+}
+
+func arborescenceArcs[K comparable, W number](ig *indexedGraph[K, W]) []darc {
+	arcs := make([]darc, len(ig.edges))
+	for i, e := range ig.edges {
+		arcs[i] = darc{from: ig.idx[e.Tail], to: ig.idx[e.Head], weight: float64(e.Weight), id: i}
+	}
+	return arcs
+}
+
+// Generate the minimum spanning arborescence of a weighted digraph rooted at root by Chu-Liu/Edmonds algorithm,
+// i.e. a spanning tree with minimum total weight in which every vertex is reachable from root along tree arcs.
+// Return the arborescence as a directed tree (rooted at root) and its weight.
+// If some vertex is unreachable from root, an error will be returned.
+func MinWeightArborescence[K comparable, W number](g Digraph[K, W], root K) (*Forest[K, W], W, error) {
+	var w W
+	if g == nil {
+		return nil, w, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, w, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	r, ok := ig.idx[root]
+	if !ok {
+		return nil, w, errVertexNotExists
+	}
+	sel, ok := edmonds(ig.order(), r, arborescenceArcs(ig))
+	if !ok {
+		return nil, w, errNoArborescence
+	}
+	return arborescenceForest(g, ig, r, sel)
+}
+
+// Generate the minimum spanning arborescence of a weighted digraph over all possible roots.
+// A virtual root is added with an arc to every vertex whose weight is larger than the total weight of all arcs,
+// so the minimum arborescence of the new digraph uses exactly one virtual arc if some spanning arborescence exists,
+// and the head of the virtual arc is the optimal root.
+// Return the arborescence as a directed tree and its weight, the root can be obtained by Forest.AllRoots.
+func MinWeightArborescenceAnyRoot[K comparable, W number](g Digraph[K, W]) (*Forest[K, W], W, error) {
+	var w W
+	if g == nil {
+		return nil, w, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, w, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	n := ig.order()
+	if n == 0 {
+		return nil, w, errEmptyGraph
+	}
+	arcs := arborescenceArcs(ig)
+	big := 1.0
+	for _, a := range arcs {
+		big += math.Abs(a.weight)
+	}
+	for v := 0; v < n; v++ {
+		arcs = append(arcs, darc{from: n, to: v, weight: big, id: len(ig.edges) + v})
+	}
+	sel, ok := edmonds(n+1, n, arcs)
+	if !ok {
+		return nil, w, errNoArborescence
+	}
+	root := -1
+	var tree []int
+	for _, i := range sel {
+		if i < len(ig.edges) {
+			tree = append(tree, i)
+			continue
+		}
+		if root != -1 {
+			return nil, w, errNoArborescence
+		}
+		root = i - len(ig.edges)
+	}
+	return arborescenceForest(g, ig, root, tree)
+}
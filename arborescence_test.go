@@ -0,0 +1,110 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// the minimum arborescence weight by enumerating the incoming arc of every vertex, -1 if not exists.
+func bruteForceArborescence(n, root int, es []Edge[int, int]) int {
+	in := make([][]Edge[int, int], n)
+	for _, e := range es {
+		if e.Tail != e.Head && e.Head != root {
+			in[e.Head] = append(in[e.Head], e)
+		}
+	}
+	best := -1
+	parent := make([]int, n)
+	var search func(v, w int)
+	search = func(v, w int) {
+		if v == n {
+			for u := 0; u < n; u++ {
+				x := u
+				for i := 0; i < n && x != root; i++ {
+					x = parent[x]
+				}
+				if x != root {
+					return
+				}
+			}
+			if best == -1 || w < best {
+				best = w
+			}
+			return
+		}
+		if v == root {
+			search(v+1, w)
+			return
+		}
+		for _, e := range in[v] {
+			parent[v] = e.Tail
+			search(v+1, w+e.Weight)
+		}
+	}
+	search(0, 0)
+	return best
+}
+
+func TestMinWeightArborescence(t *testing.T) {
+	const n = 5
+	for round := 0; round < 100; round++ {
+		g := NewDigraph[int, int]("")
+		for i := 0; i < n; i++ {
+			_ = g.AddVertex(Vertex[int, int]{Key: i})
+		}
+		for i := 0; i < 10; i++ {
+			_ = g.AddEdge(Edge[int, int]{Key: i, Tail: rand.Intn(n), Head: rand.Intn(n), Weight: rand.Intn(10)})
+		}
+		es := g.AllEdges()
+		best := -1
+		for r := 0; r < n; r++ {
+			expect := bruteForceArborescence(n, r, es)
+			f, w, err := MinWeightArborescence(g, r)
+			if expect == -1 {
+				if err == nil {
+					panic(fmt.Sprintf("arborescence rooted at %d should not exist", r))
+				}
+				continue
+			}
+			if err != nil {
+				panic(err.Error())
+			}
+			if w != expect || f.Size() != n-1 || !f.IsTree() || !f.IsDirected(r) {
+				panic(fmt.Sprintf("arborescence rooted at %d should have weight %d,but get %d", r, expect, w))
+			}
+			if best == -1 || expect < best {
+				best = expect
+			}
+		}
+		f, w, err := MinWeightArborescenceAnyRoot(g)
+		if best == -1 {
+			if err == nil {
+				panic("arborescence should not exist")
+			}
+			continue
+		}
+		if err != nil {
+			panic(err.Error())
+		}
+		if w != best || len(f.AllRoots()) != 1 {
+			panic(fmt.Sprintf("optimal arborescence should have weight %d,but get %d", best, w))
+		}
+	}
+}
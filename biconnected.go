@@ -0,0 +1,249 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "fmt"
+
+// incident returns the arcs incident to v, the direction of arcs is ignored.
+func (g *indexedGraph[K, W]) incident(v int) []arc {
+	if !g.digraph {
+		return g.out[v]
+	}
+	as := make([]arc, 0, len(g.out[v])+len(g.in[v]))
+	as = append(as, g.out[v]...)
+	return append(as, g.in[v]...)
+}
+
+// lowpoint is the result of a dfs of the underlying undirected graph,
+// blocks records the edges (index) of every biconnected component,
+// and components records the vertexes (index) of every 2-edge-connected component.
+type lowpoint struct {
+	blocks     [][]int
+	components [][]int
+}
+
+/*
+Run dfs on the underlying undirected graph, let disc[v] denote the entry time of v,
+and low[v] denote the earliest entry time that v and its descendants can reach with a back edge.
+The parent edge is skipped by its index rather than the parent vertex, so parallel edges are handled correctly.
+
+For a tree edge (v,w), if low[w] >= disc[v] then v separates the subtree of w from the rest of the graph,
+so the edges pushed on the edge stack since (v,w) form a block.
+If low[v] == disc[v] then no edge of the subtree of v reaches an ancestor of v, so the edge from the parent is a bridge,
+and the vertexes pushed on the vertex stack since v form a 2-edge-connected component.
+Loops are ignored.
+*/
+func (g *indexedGraph[K, W]) lowpoint() *lowpoint {
+	n := g.order()
+	disc := make([]int, n)
+	low := make([]int, n)
+	for i := range disc {
+		disc[i] = -1
+	}
+	res := &lowpoint{}
+	var edges, vertexes []int
+	var t int
+	var dfs func(v, pe int)
+	dfs = func(v, pe int) {
+		disc[v], low[v] = t, t
+		t++
+		vertexes = append(vertexes, v)
+		for _, a := range g.incident(v) {
+			w := a.to
+			if a.edge == pe || w == v {
+				continue
+			}
+			if disc[w] == -1 {
+				edges = append(edges, a.edge)
+				dfs(w, a.edge)
+				low[v] = min(low[v], low[w])
+				if low[w] >= disc[v] {
+					var b []int
+					for {
+						e := edges[len(edges)-1]
+						edges = edges[:len(edges)-1]
+						b = append(b, e)
+						if e == a.edge {
+							break
+						}
+					}
+					res.blocks = append(res.blocks, b)
+				}
+			} else if disc[w] < disc[v] {
+				// back edge to an ancestor.
+				edges = append(edges, a.edge)
+				low[v] = min(low[v], disc[w])
+			}
+		}
+		if low[v] == disc[v] {
+			var c []int
+			for {
+				u := vertexes[len(vertexes)-1]
+				vertexes = vertexes[:len(vertexes)-1]
+				c = append(c, u)
+				if u == v {
+					break
+				}
+			}
+			res.components = append(res.components, c)
+		}
+	}
+	for v := 0; v < n; v++ {
+		if disc[v] == -1 {
+			dfs(v, -1)
+		}
+	}
+	return res
+}
+
+// Find all biconnected components (blocks) of graph g, every block is returned as its edge set.
+// A block is a maximal connected subgraph without cut vertex, two blocks share at most one vertex (a cut vertex),
+// and every edge belongs to exactly one block except loops, which are ignored.
+// Isolated vertexes have no edge, so they do not appear in the result.
+// The direction of arcs is ignored.
+func BiconnectedComponents[K comparable, W number](g Graph[K, W]) ([][]Edge[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	lp := ig.lowpoint()
+	res := make([][]Edge[K, W], len(lp.blocks))
+	for i, b := range lp.blocks {
+		res[i] = make([]Edge[K, W], len(b))
+		for j, e := range b {
+			res[i][j] = ig.edges[e]
+		}
+	}
+	return res, nil
+}
+
+// Find all 2-edge-connected components of graph g, every component is returned as its vertex set.
+// The components are obtained by removing all bridges, so every vertex belongs to exactly one component.
+// The direction of arcs is ignored.
+func TwoEdgeConnectedComponents[K comparable, W number](g Graph[K, W]) ([][]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	lp := ig.lowpoint()
+	res := make([][]K, len(lp.components))
+	for i, c := range lp.components {
+		res[i] = make([]K, len(c))
+		for j, v := range c {
+			res[i][j] = ig.keys[v]
+		}
+	}
+	return res, nil
+}
+
+// Check if graph g is biconnected, i.e. g is connected, has at least two vertexes and has no cut vertex.
+// The direction of arcs and loops are ignored.
+func IsBiconnected[K comparable, W number](g Graph[K, W]) (bool, error) {
+	if g == nil {
+		return false, errNilGraph
+	}
+	return newIndexedGraph(g).isBiconnected(), nil
+}
+
+func (g *indexedGraph[K, W]) isBiconnected() bool {
+	if g.order() < 2 {
+		return false
+	}
+	lp := g.lowpoint()
+	if len(lp.blocks) != 1 {
+		return false
+	}
+	vs := make(map[int]struct{})
+	for _, e := range lp.blocks[0] {
+		vs[g.idx[g.edges[e].Head]] = struct{}{}
+		vs[g.idx[g.edges[e].Tail]] = struct{}{}
+	}
+	return len(vs) == g.order()
+}
+
+/*
+Build the block-cut tree of graph g: there is a node for every block and a node for every cut vertex,
+and a block node is adjacent to a cut vertex node if the block contains the cut vertex.
+If g is connected the result is a tree, otherwise it's a forest with a tree for every connected component
+(isolated vertexes are ignored since they have no block).
+
+The nodes are numbered from 0, block nodes come first:
+the node i (i < len(blocks)) is the block blocks[i] (its edge set) and its label "type" is "block",
+the node c (c >= len(blocks)) is the cut vertex cuts[c] and its label "type" is "cut".
+*/
+func BlockCutTree[K comparable, W number](g Graph[K, W]) (*Forest[int, int], [][]Edge[K, W], map[int]K, error) {
+	if g == nil {
+		return nil, nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	lp := ig.lowpoint()
+	// vertexes of every block.
+	members := make([][]int, len(lp.blocks))
+	count := make([]int, ig.order())
+	for i, b := range lp.blocks {
+		vs := make(map[int]struct{})
+		for _, e := range b {
+			for _, v := range []int{ig.idx[ig.edges[e].Head], ig.idx[ig.edges[e].Tail]} {
+				if _, ok := vs[v]; !ok {
+					vs[v] = struct{}{}
+					members[i] = append(members[i], v)
+					count[v]++
+				}
+			}
+		}
+	}
+	f := NewForest[int, int]()
+	blocks := make([][]Edge[K, W], len(lp.blocks))
+	for i, b := range lp.blocks {
+		blocks[i] = make([]Edge[K, W], len(b))
+		for j, e := range b {
+			blocks[i][j] = ig.edges[e]
+		}
+		if err := f.AddVertex(Vertex[int, int]{Key: i, Labels: map[string]string{"type": "block"}}); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	cut := make(map[int]int)
+	cuts := make(map[int]K)
+	for v, c := range count {
+		if c > 1 {
+			cut[v] = len(lp.blocks) + len(cut)
+			cuts[cut[v]] = ig.keys[v]
+			err := f.AddVertex(Vertex[int, int]{
+				Key: cut[v],
+				Labels: map[string]string{
+					"type":   "cut",
+					"vertex": fmt.Sprintf("%v", ig.keys[v]),
+				}})
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+	}
+	var ek int
+	for i, vs := range members {
+		for _, v := range vs {
+			if c, ok := cut[v]; ok {
+				if err := f.AddEdge(Edge[int, int]{Key: ek, Head: i, Tail: c}); err != nil {
+					return nil, nil, nil, err
+				}
+				ek++
+			}
+		}
+	}
+	return f, blocks, cuts, nil
+}
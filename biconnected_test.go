@@ -0,0 +1,111 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+// two triangles 0-1-2 and 2-3-4 share the vertex 2, and a pendant edge 4-5.
+func testBowtie() Graph[int, int] {
+	g := NewGraph[int, int](false, "bowtie")
+	for i := 0; i < 6; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 2}, {4, 5}} {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Head: e[0], Tail: e[1]})
+	}
+	return g
+}
+
+func TestBiconnectedComponents(t *testing.T) {
+	g := testBowtie()
+	bs, err := BiconnectedComponents(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(bs) != 3 {
+		panic(fmt.Sprintf("there should be 3 blocks,but get %d", len(bs)))
+	}
+	sizes := make(map[int]int)
+	for _, b := range bs {
+		sizes[len(b)]++
+	}
+	if sizes[3] != 2 || sizes[1] != 1 {
+		panic(fmt.Sprintf("wrong blocks %v", bs))
+	}
+	cs, err := TwoEdgeConnectedComponents(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(cs) != 2 {
+		panic(fmt.Sprintf("there should be 2 2-edge-connected components,but get %v", cs))
+	}
+	tree, blocks, cuts, err := BlockCutTree(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if tree.Order() != 5 || tree.Size() != 4 || !tree.IsTree() {
+		panic(fmt.Sprintf("wrong block-cut tree order=%d size=%d", tree.Order(), tree.Size()))
+	}
+	if len(blocks) != 3 || len(cuts) != 2 {
+		panic(fmt.Sprintf("wrong block-cut tree blocks=%v cuts=%v", blocks, cuts))
+	}
+	for _, v := range tree.AllVertexes() {
+		if v.Key < len(blocks) {
+			if v.Labels["type"] != "block" {
+				panic(fmt.Sprintf("node %d should be a block", v.Key))
+			}
+			continue
+		}
+		if k, ok := cuts[v.Key]; !ok || v.Labels["type"] != "cut" || (k != 2 && k != 4) {
+			panic(fmt.Sprintf("node %d is not cut vertex", v.Key))
+		}
+	}
+	// a block node is adjacent to the cut vertexes it contains.
+	for _, e := range tree.AllEdges() {
+		b, c := e.Head, e.Tail
+		if b >= len(blocks) {
+			b, c = c, b
+		}
+		var found bool
+		for _, be := range blocks[b] {
+			if be.Head == cuts[c] || be.Tail == cuts[c] {
+				found = true
+			}
+		}
+		if !found {
+			panic(fmt.Sprintf("block %v does not contain cut vertex %v", blocks[b], cuts[c]))
+		}
+	}
+
+	p, err := g.Property(ProBiconnected)
+	if err != nil {
+		panic(err.Error())
+	}
+	if p.Value.(bool) {
+		panic("bowtie is not biconnected")
+	}
+	p, err = Cycle(5).Property(ProBiconnected)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !p.Value.(bool) {
+		panic("cycle is biconnected")
+	}
+}
@@ -0,0 +1,549 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CanonicalOptions specifies which attributes of a graph are part of its shape.
+// By default only the structure (vertexes, edges, directions and multiplicity) is considered.
+type CanonicalOptions struct {
+	// Take vertex labels and edge labels into account.
+	Labels bool
+	// Take vertex weights and edge weights into account.
+	Weights bool
+}
+
+// CanonicalLabelling is the result of canonical labelling of a graph.
+// Two graphs are isomorphic if and only if their certificates are equal.
+type CanonicalLabelling[K comparable, W number] struct {
+	// The relabelled graph, the key of a vertex is its canonical index,
+	// and the key of an edge is its index in canonical edge order.
+	Graph Graph[int, W]
+	// Map original vertex keys to canonical indexes.
+	Vertexes map[K]int
+	// Map original edge keys to canonical edge keys.
+	Edges map[K]int
+	// The certificate is the JSON serialization of the canonical graph
+	// (vertex and edge values are omitted), so it can be persisted directly,
+	// or loaded by UnmarshalGraph[int, W].
+	Certificate string
+}
+
+type canonArc struct {
+	to   int
+	dir  int // 0: out (or undirected), 1: in
+	attr int
+}
+
+// canonGraph is an int indexed snapshot of a graph used by colour refinement.
+type canonGraph[K comparable, W number] struct {
+	digraph bool
+	opts    CanonicalOptions
+	vs      []Vertex[K, W]
+	es      []Edge[K, W]
+	idx     map[K]int
+	adj     [][]canonArc
+	vattr   []string
+	eattr   []string // attribute of each edge
+	vrank   []int    // rank of the attribute of each vertex
+	erank   []int    // rank of the attribute of each edge
+}
+
+func newCanonGraph[K comparable, W number](g Graph[K, W], opts *CanonicalOptions) *canonGraph[K, W] {
+	c := &canonGraph[K, W]{
+		digraph: g.IsDigraph(),
+		vs:      g.AllVertexes(),
+		es:      g.AllEdges(),
+		idx:     make(map[K]int),
+	}
+	if opts != nil {
+		c.opts = *opts
+	}
+	c.adj = make([][]canonArc, len(c.vs))
+	c.vattr = make([]string, len(c.vs))
+	for i, v := range c.vs {
+		c.idx[v.Key] = i
+		c.vattr[i] = c.attribute(v.Labels, v.Weight)
+	}
+	// number edge attributes by their sorted order so that the numbers are invariant.
+	c.eattr = make([]string, len(c.es))
+	attrs := make(map[string]int)
+	for i, e := range c.es {
+		c.eattr[i] = c.attribute(e.Labels, e.Weight)
+		attrs[c.eattr[i]] = 0
+	}
+	keys := make([]string, 0, len(attrs))
+	for a := range attrs {
+		keys = append(keys, a)
+	}
+	sort.Strings(keys)
+	for i, a := range keys {
+		attrs[a] = i
+	}
+	c.vrank, _ = rankStrings(c.vattr)
+	c.erank = make([]int, len(c.es))
+	for i, e := range c.es {
+		t, h, a := c.idx[e.Tail], c.idx[e.Head], attrs[c.eattr[i]]
+		c.erank[i] = a
+		c.adj[t] = append(c.adj[t], canonArc{to: h, dir: 0, attr: a})
+		if c.digraph {
+			c.adj[h] = append(c.adj[h], canonArc{to: t, dir: 1, attr: a})
+		} else if t != h {
+			c.adj[h] = append(c.adj[h], canonArc{to: t, dir: 0, attr: a})
+		}
+	}
+	return c
+}
+
+func (c *canonGraph[K, W]) attribute(labels map[string]string, weight W) string {
+	var sb strings.Builder
+	if c.opts.Labels {
+		ks := make([]string, 0, len(labels))
+		for k := range labels {
+			ks = append(ks, k)
+		}
+		sort.Strings(ks)
+		for _, k := range ks {
+			sb.WriteString(strconv.Quote(k))
+			sb.WriteByte('=')
+			sb.WriteString(strconv.Quote(labels[k]))
+			sb.WriteByte(';')
+		}
+	}
+	if c.opts.Weights {
+		sb.WriteString(fmt.Sprintf("w=%v", weight))
+	}
+	return sb.String()
+}
+
+// Refine the ordered partition represented by colours until it is equitable,
+// the new colour of a vertex is determined by its old colour and the multiset of (direction,edge,colour) of its neighbours.
+// The returned colours are ranks, i.e. 0..k-1, and the order of the old colours is kept.
+func (c *canonGraph[K, W]) refine(colours []int) []int {
+	n := len(colours)
+	cur := append([]int(nil), colours...)
+	cells := -1
+	for {
+		sigs := make([]string, n)
+		for i := 0; i < n; i++ {
+			ns := make([]string, len(c.adj[i]))
+			for j, a := range c.adj[i] {
+				ns[j] = fmt.Sprintf("%d.%d.%d", a.dir, a.attr, cur[a.to])
+			}
+			sort.Strings(ns)
+			sigs[i] = fmt.Sprintf("%012d|%s", cur[i], strings.Join(ns, ","))
+		}
+		next, k := rankStrings(sigs)
+		cur = next
+		if k == cells {
+			return cur
+		}
+		cells = k
+	}
+}
+
+func rankStrings(sigs []string) ([]int, int) {
+	uniq := make([]string, 0, len(sigs))
+	seen := make(map[string]int)
+	for _, s := range sigs {
+		if _, ok := seen[s]; !ok {
+			seen[s] = 0
+			uniq = append(uniq, s)
+		}
+	}
+	sort.Strings(uniq)
+	for i, s := range uniq {
+		seen[s] = i
+	}
+	res := make([]int, len(sigs))
+	for i, s := range sigs {
+		res[i] = seen[s]
+	}
+	return res, len(uniq)
+}
+
+// Build the canonical GraphInfo from a discrete colouring (colours[i] is the canonical index of vertex i).
+func (c *canonGraph[K, W]) certificate(colours []int) (GraphInfo[int, W], []int) {
+	gi := GraphInfo[int, W]{
+		Digraph:  c.digraph,
+		Vertexes: make([]Vertex[int, W], len(c.vs)),
+		Edges:    make([]Edge[int, W], len(c.es)),
+	}
+	for i, v := range c.vs {
+		nv := Vertex[int, W]{Key: colours[i]}
+		if c.opts.Labels && len(v.Labels) != 0 {
+			nv.Labels = v.Labels
+		}
+		if c.opts.Weights {
+			nv.Weight = v.Weight
+		}
+		gi.Vertexes[colours[i]] = nv
+	}
+	order := make([]int, len(c.es))
+	for i, e := range c.es {
+		order[i] = i
+		t, h := colours[c.idx[e.Tail]], colours[c.idx[e.Head]]
+		if !c.digraph && t > h {
+			t, h = h, t
+		}
+		ne := Edge[int, W]{Tail: t, Head: h}
+		if c.opts.Labels && len(e.Labels) != 0 {
+			ne.Labels = e.Labels
+		}
+		if c.opts.Weights {
+			ne.Weight = e.Weight
+		}
+		gi.Edges[i] = ne
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := gi.Edges[order[i]], gi.Edges[order[j]]
+		if a.Tail != b.Tail {
+			return a.Tail < b.Tail
+		}
+		if a.Head != b.Head {
+			return a.Head < b.Head
+		}
+		return c.eattr[order[i]] < c.eattr[order[j]]
+	})
+	es := make([]Edge[int, W], len(order))
+	for k, i := range order {
+		es[k] = gi.Edges[i]
+		es[k].Key = k
+	}
+	gi.Edges = es
+	return gi, order
+}
+
+// canonSearch keeps the state of the individualization-refinement search.
+type canonSearch[K comparable, W number] struct {
+	c *canonGraph[K, W]
+	// the first leaf and the best leaf found so far,
+	// a leaf is represented by its colouring, its key and the sequence of individualized vertexes.
+	first, best         []int
+	firstKey, bestKey   []int
+	firstPath, bestPath []int
+	automorphisms       [][]int
+}
+
+// The key of a leaf (discrete colouring) is the relabelled graph encoded as integers:
+// attributes of vertexes in canonical order followed by the sorted (tail,head,attribute) of edges.
+// Two leaves have the same key if and only if they produce the same relabelled graph.
+func (c *canonGraph[K, W]) leafKey(colours []int) []int {
+	n, m := len(c.vs), len(c.es)
+	key := make([]int, n, n+3*m)
+	for i := range c.vs {
+		key[colours[i]] = c.vrank[i]
+	}
+	es := make([][3]int, m)
+	for i, e := range c.es {
+		t, h := colours[c.idx[e.Tail]], colours[c.idx[e.Head]]
+		if !c.digraph && t > h {
+			t, h = h, t
+		}
+		es[i] = [3]int{t, h, c.erank[i]}
+	}
+	sort.Slice(es, func(i, j int) bool {
+		a, b := es[i], es[j]
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		if a[1] != b[1] {
+			return a[1] < b[1]
+		}
+		return a[2] < b[2]
+	})
+	for _, e := range es {
+		key = append(key, e[0], e[1], e[2])
+	}
+	return key
+}
+
+func compareInts(a, b []int) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+func commonPrefix(a, b []int) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Record the automorphism which maps the leaf from to the leaf to,
+// i.e. the vertex x is mapped to the vertex which has the same canonical index in to as x in from.
+func (s *canonSearch[K, W]) record(from, to []int) {
+	inv := make([]int, len(to))
+	for v, k := range to {
+		inv[k] = v
+	}
+	p := make([]int, len(from))
+	for v, k := range from {
+		p[v] = inv[k]
+	}
+	s.automorphisms = append(s.automorphisms, p)
+}
+
+// Calculate the orbits of the group generated by the known automorphisms which fix the path pointwise.
+func (s *canonSearch[K, W]) orbits(path []int) []int {
+	n := len(s.c.vs)
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(x int) int
+	find = func(x int) int {
+		for parent[x] != x {
+			parent[x] = parent[parent[x]]
+			x = parent[x]
+		}
+		return x
+	}
+	for _, p := range s.automorphisms {
+		fixed := true
+		for _, v := range path {
+			if p[v] != v {
+				fixed = false
+				break
+			}
+		}
+		if !fixed {
+			continue
+		}
+		for v, u := range p {
+			if a, b := find(v), find(u); a != b {
+				parent[a] = b
+			}
+		}
+	}
+	for i := range parent {
+		parent[i] = find(i)
+	}
+	return parent
+}
+
+// Search the leaf with the smallest key over the search tree of individualization-refinement,
+// the first non-singleton cell is chosen as target cell.
+// Two kinds of pruning are used:
+// if a leaf has the same key as the first leaf (or the best leaf), the mapping between them is an automorphism
+// which maps the explored subtree at their common ancestor onto the current one, so the search jumps back to that ancestor;
+// the candidates of a target cell which are in the same orbit (under the automorphisms fixing the path) as an explored candidate are skipped.
+// The returned value is the depth where the search continues.
+func (s *canonSearch[K, W]) search(colours []int, path []int) int {
+	c := s.c
+	colours = c.refine(colours)
+	n := len(colours)
+	depth := len(path)
+	count := make([]int, n)
+	for _, k := range colours {
+		count[k]++
+	}
+	target := -1
+	for k := 0; k < n; k++ {
+		if count[k] > 1 {
+			target = k
+			break
+		}
+	}
+	if target == -1 {
+		key := c.leafKey(colours)
+		if s.first == nil {
+			s.first, s.firstKey, s.firstPath = colours, key, append([]int(nil), path...)
+			s.best, s.bestKey, s.bestPath = s.first, s.firstKey, s.firstPath
+			return depth
+		}
+		if compareInts(key, s.firstKey) == 0 {
+			s.record(s.first, colours)
+			return commonPrefix(path, s.firstPath)
+		}
+		switch compareInts(key, s.bestKey) {
+		case 0:
+			s.record(s.best, colours)
+			return commonPrefix(path, s.bestPath)
+		case -1:
+			s.best, s.bestKey, s.bestPath = colours, key, append([]int(nil), path...)
+		}
+		return depth
+	}
+	var explored []int
+	for v := 0; v < n; v++ {
+		if colours[v] != target {
+			continue
+		}
+		if len(explored) != 0 {
+			orbits := s.orbits(path)
+			skip := false
+			for _, u := range explored {
+				if orbits[u] == orbits[v] {
+					skip = true
+					break
+				}
+			}
+			if skip {
+				continue
+			}
+		}
+		explored = append(explored, v)
+		// individualize v: v goes before the other vertexes in its cell.
+		next := make([]int, n)
+		for u := 0; u < n; u++ {
+			next[u] = 2*colours[u] + 1
+		}
+		next[v] = 2 * colours[v]
+		if d := s.search(next, append(path, v)); d < depth {
+			return d
+		}
+	}
+	return depth
+}
+
+// Calculate the canonical form of the graph: isomorphic graphs (with respect to the attributes specified by opts)
+// get the same relabelled graph and the same certificate.
+// The search prunes the branches which are equivalent under the automorphisms found during the search,
+// but it's still exponential in the worst case, so it's intended for small and medium graphs,
+// use WLHash as a cheap invariant for large graphs.
+func CanonicalForm[K comparable, W number](g Graph[K, W], opts *CanonicalOptions) (*CanonicalLabelling[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	c := newCanonGraph(g, opts)
+	s := &canonSearch[K, W]{c: c}
+	if len(c.vs) != 0 {
+		s.search(append([]int(nil), c.vrank...), nil)
+	} else {
+		s.best = []int{}
+	}
+	bestColours := s.best
+	gi, order := c.certificate(bestColours)
+	best, err := json.Marshal(gi)
+	if err != nil {
+		return nil, err
+	}
+	res := &CanonicalLabelling[K, W]{
+		Vertexes:    make(map[K]int),
+		Edges:       make(map[K]int),
+		Certificate: string(best),
+	}
+	ng := NewGraph[int, W](c.digraph, g.Name()+"_canonical")
+	inv := make([]int, len(c.vs))
+	for i, v := range c.vs {
+		res.Vertexes[v.Key] = bestColours[i]
+		inv[bestColours[i]] = i
+	}
+	for _, nv := range gi.Vertexes {
+		v := c.vs[inv[nv.Key]]
+		cv := v.Clone()
+		if err := ng.AddVertex(Vertex[int, W]{Key: nv.Key, Value: cv.Value, Weight: v.Weight, Labels: cv.Labels}); err != nil {
+			return nil, err
+		}
+	}
+	for k, i := range order {
+		e := c.es[i].Clone()
+		res.Edges[e.Key] = k
+		ne := gi.Edges[k]
+		if err := ng.AddEdge(Edge[int, W]{Key: k, Head: ne.Head, Tail: ne.Tail, Weight: e.Weight, Value: e.Value, Labels: e.Labels}); err != nil {
+			return nil, err
+		}
+	}
+	res.Graph = ng
+	return res, nil
+}
+
+// Calculate the certificate of the graph, see CanonicalForm.
+func Certificate[K comparable, W number](g Graph[K, W], opts *CanonicalOptions) (string, error) {
+	c, err := CanonicalForm(g, opts)
+	if err != nil {
+		return "", err
+	}
+	return c.Certificate, nil
+}
+
+// Calculate the Weisfeiler-Lehman hash of the graph.
+// In every iteration the label of a vertex is replaced by the hash of its label and the sorted labels
+// of its neighbours (together with edge direction and edge attributes),
+// the result is the hash of the label histograms of all iterations.
+// Isomorphic graphs always have the same hash, but graphs with the same hash are not necessarily isomorphic.
+func WLHash[K comparable, W number](g Graph[K, W], iterations int, opts *CanonicalOptions) (string, error) {
+	if g == nil {
+		return "", errNilGraph
+	}
+	c := newCanonGraph(g, opts)
+	n := len(c.vs)
+	// edge attributes of arcs, use the attribute string instead of the number
+	// because numbers are only comparable inside a graph.
+	eattr := make([][]string, n)
+	for i := range eattr {
+		eattr[i] = make([]string, len(c.adj[i]))
+	}
+	pos := make([]int, n)
+	for i, e := range c.es {
+		t, h := c.idx[e.Tail], c.idx[e.Head]
+		eattr[t][pos[t]] = c.eattr[i]
+		pos[t]++
+		if c.digraph || t != h {
+			eattr[h][pos[h]] = c.eattr[i]
+			pos[h]++
+		}
+	}
+	hash := func(s string) string {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:16])
+	}
+	labels := make([]string, n)
+	for i := range labels {
+		labels[i] = hash(c.vattr[i])
+	}
+	histogram := func(ls []string) string {
+		hs := append([]string(nil), ls...)
+		sort.Strings(hs)
+		return strings.Join(hs, ",")
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%v;%d;%d;", c.digraph, n, len(c.es)))
+	sb.WriteString(histogram(labels))
+	for it := 0; it < iterations; it++ {
+		next := make([]string, n)
+		for i := 0; i < n; i++ {
+			ns := make([]string, len(c.adj[i]))
+			for j, a := range c.adj[i] {
+				ns[j] = fmt.Sprintf("%d.%s.%s", a.dir, strconv.Quote(eattr[i][j]), labels[a.to])
+			}
+			sort.Strings(ns)
+			next[i] = hash(labels[i] + "|" + strings.Join(ns, ","))
+		}
+		labels = next
+		sb.WriteByte(';')
+		sb.WriteString(histogram(labels))
+	}
+	return hash(sb.String()), nil
+}
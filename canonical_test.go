@@ -0,0 +1,162 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testTwoTriangles() Graph[int, int] {
+	g := NewGraph[int, int](false, "2C3")
+	for i := 0; i < 6; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	es := []Edge[int, int]{
+		{Head: 0, Tail: 1},
+		{Head: 1, Tail: 2},
+		{Head: 2, Tail: 0},
+		{Head: 3, Tail: 4},
+		{Head: 4, Tail: 5},
+		{Head: 5, Tail: 3},
+	}
+	for i, e := range es {
+		e.Key = i
+		if err := g.AddEdge(e); err != nil {
+			panic(err.Error())
+		}
+	}
+	return g
+}
+
+func TestCanonicalForm(t *testing.T) {
+	g := NewGraph[int, int](false, "")
+	for i := 0; i < 10; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for _, e := range PetersenGraph().AllEdges() {
+		e.Head, e.Tail = (7*e.Head+1)%10, (7*e.Tail+1)%10
+		if err := g.AddEdge(e); err != nil {
+			panic(err.Error())
+		}
+	}
+	c1, err := CanonicalForm(PetersenGraph(), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	c2, err := CanonicalForm(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if c1.Certificate != c2.Certificate {
+		panic("isomorphic graphs should have same certificate")
+	}
+	// the certificate can be loaded as a graph.
+	cg, err := UnmarshalGraph[int, int]([]byte(c1.Certificate))
+	if err != nil {
+		panic(err.Error())
+	}
+	ok, err := IsIsomorphic(cg, PetersenGraph())
+	if err != nil {
+		panic(err.Error())
+	}
+	if !ok {
+		panic("certificate graph should be isomorphic to the origin graph")
+	}
+	c3, err := CanonicalForm(Cycle(6), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	c4, err := CanonicalForm(testTwoTriangles(), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if c3.Certificate == c4.Certificate {
+		panic("C6 and 2C3 should have different certificates")
+	}
+	fmt.Println(c3.Certificate)
+}
+
+func TestWLHash(t *testing.T) {
+	h1, err := WLHash(Cycle(6), 3, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	h2, err := WLHash(testTwoTriangles(), 3, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	// 1-WL can not distinguish regular graphs with same degree.
+	if h1 != h2 {
+		panic("C6 and 2C3 should have same WL hash")
+	}
+	g := Cycle(6)
+	_ = g.SetVertexLabel(0, "color", "red")
+	h3, err := WLHash(g, 3, &CanonicalOptions{Labels: true})
+	if err != nil {
+		panic(err.Error())
+	}
+	if h3 == h1 {
+		panic("labels should change the WL hash")
+	}
+	h4, err := WLHash(g, 3, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if h4 != h1 {
+		panic("labels should be ignored")
+	}
+}
+
+// Highly symmetric graphs have a huge number of leaves in the search tree,
+// the automorphism pruning keeps the search small.
+func TestCanonicalFormSymmetric(t *testing.T) {
+	empty := NewGraph[int, int](false, "E12")
+	for i := 0; i < 12; i++ {
+		_ = empty.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for _, g := range []Graph[int, int]{empty, CompleteGraph(12), Cycle(12), CompleteKpartite([]int{4, 4, 4})} {
+		// relabel the vertexes by v -> 11-v.
+		h := NewGraph[int, int](false, "")
+		for i := 11; i >= 0; i-- {
+			_ = h.AddVertex(Vertex[int, int]{Key: i})
+		}
+		for _, e := range g.AllEdges() {
+			e.Head, e.Tail = 11-e.Head, 11-e.Tail
+			if err := h.AddEdge(e); err != nil {
+				panic(err.Error())
+			}
+		}
+		start := time.Now()
+		c1, err := CanonicalForm(g, nil)
+		if err != nil {
+			panic(err.Error())
+		}
+		c2, err := CanonicalForm(h, nil)
+		if err != nil {
+			panic(err.Error())
+		}
+		if c1.Certificate != c2.Certificate {
+			panic(fmt.Sprintf("%s: isomorphic graphs should have same certificate", g.Name()))
+		}
+		if d := time.Since(start); d > 5*time.Second {
+			panic(fmt.Sprintf("%s: canonical form is too slow: %v", g.Name(), d))
+		}
+	}
+	fmt.Println("=======> test canonical form of symmetric graphs pass")
+}
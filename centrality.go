@@ -0,0 +1,512 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "math"
+
+// CentralityOptions controls the computation of centrality measures,
+// zero values mean the defaults.
+type CentralityOptions[K comparable] struct {
+	// Use Edge.Weight: as the length of an edge for path based measures
+	// (betweenness, closeness, harmonic), or as the strength of an edge
+	// for spectral measures (eigenvector, Katz, PageRank, HITS).
+	Weighted bool
+	// Normalize the result, see the description of every measure.
+	Normalized bool
+	// The maximum number of iterations of iterative measures, default is 100.
+	MaxIterations int
+	// The convergence tolerance of iterative measures, default is 1e-6.
+	Tolerance float64
+	// The damping factor of PageRank, default is 0.85.
+	Damping float64
+	// The attenuation factor of Katz centrality, default is 0.1.
+	Alpha float64
+	// The constant term of Katz centrality, default is 1.0.
+	Beta float64
+	// The personalization vector of PageRank, the random walk restarts at
+	// vertex v with probability proportional to Personalization[v].
+	// If it is nil, the restart distribution is uniform.
+	Personalization map[K]float64
+}
+
+func (o *CentralityOptions[K]) withDefaults() CentralityOptions[K] {
+	var opts CentralityOptions[K]
+	if o != nil {
+		opts = *o
+	}
+	if opts.MaxIterations <= 0 {
+		opts.MaxIterations = 100
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 1e-6
+	}
+	if opts.Damping <= 0 {
+		opts.Damping = 0.85
+	}
+	if opts.Alpha <= 0 {
+		opts.Alpha = 0.1
+	}
+	if opts.Beta == 0 {
+		opts.Beta = 1.0
+	}
+	return opts
+}
+
+// singleSource records the shortest path DAG from a source vertex.
+type singleSource struct {
+	order []int // vertexes in non-decreasing order of distance
+	dist  []float64
+	sigma []float64 // number of shortest paths
+	pred  [][]arc   // predecessors on shortest paths (arc.to is the predecessor)
+}
+
+// Calculate shortest paths from s by BFS (unweighted) or Dijkstra (weighted).
+func (g *indexedGraph[K, W]) shortestPathDAG(s int, weighted bool, pred bool) *singleSource {
+	n := g.order()
+	ss := &singleSource{
+		dist:  make([]float64, n),
+		sigma: make([]float64, n),
+	}
+	if pred {
+		ss.pred = make([][]arc, n)
+	}
+	for i := range ss.dist {
+		ss.dist[i] = -1
+	}
+	ss.dist[s] = 0
+	ss.sigma[s] = 1
+	if !weighted {
+		queue := newFIFO[int]()
+		queue.push(s)
+		for !queue.empty() {
+			v, _ := queue.pop()
+			ss.order = append(ss.order, v)
+			for _, a := range g.out[v] {
+				w := a.to
+				if ss.dist[w] < 0 {
+					ss.dist[w] = ss.dist[v] + 1
+					queue.push(w)
+				}
+				if ss.dist[w] == ss.dist[v]+1 {
+					ss.sigma[w] += ss.sigma[v]
+					if pred {
+						ss.pred[w] = append(ss.pred[w], arc{to: v, edge: a.edge})
+					}
+				}
+			}
+		}
+		return ss
+	}
+	done := make([]bool, n)
+	pq := NewPriorityQueue[int, float64](func(p1, p2 float64) bool { return p1 < p2 })
+	pq.Push(s, 0)
+	for pq.Len() != 0 {
+		v, d, _ := pq.Pop()
+		if done[v] || d > ss.dist[v] {
+			continue
+		}
+		done[v] = true
+		ss.order = append(ss.order, v)
+		for _, a := range g.out[v] {
+			w, nd := a.to, d+a.weight
+			if done[w] && w != v {
+				continue
+			}
+			if ss.dist[w] < 0 || nd < ss.dist[w] {
+				ss.dist[w] = nd
+				ss.sigma[w] = ss.sigma[v]
+				if pred {
+					ss.pred[w] = []arc{{to: v, edge: a.edge}}
+				}
+				pq.Push(w, nd)
+			} else if nd == ss.dist[w] && w != v {
+				ss.sigma[w] += ss.sigma[v]
+				if pred {
+					ss.pred[w] = append(ss.pred[w], arc{to: v, edge: a.edge})
+				}
+			}
+		}
+	}
+	return ss
+}
+
+// Brandes algorithm, compute vertex and edge betweenness simultaneously.
+//
+//	for s ∈ V do
+//	    S ← empty stack; P[w] ← empty list, w ∈ V; σ[t] ← 0, t ∈ V; σ[s] ← 1; d[t] ← −1, t ∈ V; d[s] ← 0
+//	    compute shortest paths from s, push vertexes to S in order of distance
+//	    δ[v] ← 0, v ∈ V
+//	    while S not empty do
+//	        pop w ← S
+//	        for v ∈ P[w] do δ[v] ← δ[v] + σ[v]/σ[w]·(1 + δ[w])
+//	        if w != s then CB[w] ← CB[w] + δ[w]
+func betweenness[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (*indexedGraph[K, W], []float64, []float64, error) {
+	if g == nil {
+		return nil, nil, nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	if o.Weighted && ig.hasNegativeWeight() {
+		return nil, nil, nil, errNegativeWeight
+	}
+	n := ig.order()
+	vb := make([]float64, n)
+	eb := make([]float64, len(ig.edges))
+	delta := make([]float64, n)
+	for s := 0; s < n; s++ {
+		ss := ig.shortestPathDAG(s, o.Weighted, true)
+		for _, v := range ss.order {
+			delta[v] = 0
+		}
+		for i := len(ss.order) - 1; i >= 0; i-- {
+			w := ss.order[i]
+			for _, p := range ss.pred[w] {
+				c := ss.sigma[p.to] / ss.sigma[w] * (1 + delta[w])
+				eb[p.edge] += c
+				delta[p.to] += c
+			}
+			if w != s {
+				vb[w] += delta[w]
+			}
+		}
+	}
+	return ig, vb, eb, nil
+}
+
+// Calculate the betweenness centrality of every vertex,
+// which is the sum of the fraction of all-pairs shortest paths that pass through the vertex.
+// If opts.Normalized is true, the values are divided by (n-1)(n-2) for digraphs and (n-1)(n-2)/2 for undirected graphs.
+func BetweennessCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	ig, vb, _, err := betweenness(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	n := float64(ig.order())
+	scale := 1.0
+	if opts != nil && opts.Normalized {
+		if n > 2 {
+			scale = 1 / ((n - 1) * (n - 2))
+		}
+	} else if !ig.digraph {
+		scale = 0.5
+	}
+	res := make(map[K]float64)
+	for i, k := range ig.keys {
+		res[k] = vb[i] * scale
+	}
+	return res, nil
+}
+
+// Calculate the betweenness centrality of every edge (the key of the result is edge key),
+// which is the sum of the fraction of all-pairs shortest paths that pass through the edge.
+// If opts.Normalized is true, the values are divided by n(n-1) for digraphs and n(n-1)/2 for undirected graphs.
+func EdgeBetweennessCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	ig, _, eb, err := betweenness(g, opts)
+	if err != nil {
+		return nil, err
+	}
+	n := float64(ig.order())
+	scale := 1.0
+	if opts != nil && opts.Normalized {
+		if n > 1 {
+			scale = 1 / (n * (n - 1))
+		}
+	} else if !ig.digraph {
+		scale = 0.5
+	}
+	res := make(map[K]float64)
+	for i, e := range ig.edges {
+		res[e.Key] = eb[i] * scale
+	}
+	return res, nil
+}
+
+func distanceCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K], f func(ss *singleSource, n int) float64) (map[K]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	if o.Weighted && ig.hasNegativeWeight() {
+		return nil, errNegativeWeight
+	}
+	res := make(map[K]float64)
+	for s, k := range ig.keys {
+		res[k] = f(ig.shortestPathDAG(s, o.Weighted, false), ig.order())
+	}
+	return res, nil
+}
+
+// Calculate the closeness centrality of every vertex, it's the reciprocal of the average distance
+// from the vertex to all reachable vertexes. For graphs that are not (strongly) connected,
+// the Wasserman and Faust improved formula is used: (r-1)/(n-1) * (r-1)/sum(d(u,v)),
+// where r is the number of vertexes reachable from u (including u).
+// For a digraph the distance is measured along outgoing arcs.
+func ClosenessCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	return distanceCentrality(g, opts, func(ss *singleSource, n int) float64 {
+		var sum float64
+		for _, v := range ss.order {
+			sum += ss.dist[v]
+		}
+		r := float64(len(ss.order) - 1)
+		if sum == 0 || n <= 1 {
+			return 0
+		}
+		return (r / sum) * (r / float64(n-1))
+	})
+}
+
+// Calculate the harmonic centrality of every vertex, it's the sum of reciprocal of the distances
+// from the vertex to all other vertexes (1/∞ = 0). If opts.Normalized is true, the values are divided by n-1.
+// For a digraph the distance is measured along outgoing arcs.
+func HarmonicCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	normalized := opts != nil && opts.Normalized
+	return distanceCentrality(g, opts, func(ss *singleSource, n int) float64 {
+		var sum float64
+		for _, v := range ss.order {
+			if ss.dist[v] > 0 {
+				sum += 1 / ss.dist[v]
+			}
+		}
+		if normalized && n > 1 {
+			sum /= float64(n - 1)
+		}
+		return sum
+	})
+}
+
+func (g *indexedGraph[K, W]) arcWeight(a arc, weighted bool) float64 {
+	if weighted {
+		return a.weight
+	}
+	return 1
+}
+
+func normalizeVector(x []float64, l2 bool) {
+	var s float64
+	for _, v := range x {
+		if l2 {
+			s += v * v
+		} else {
+			s += math.Abs(v)
+		}
+	}
+	if l2 {
+		s = math.Sqrt(s)
+	}
+	if s == 0 {
+		return
+	}
+	for i := range x {
+		x[i] /= s
+	}
+}
+
+func vectorToMap[K comparable](keys []K, x []float64) map[K]float64 {
+	res := make(map[K]float64)
+	for i, k := range keys {
+		res[k] = x[i]
+	}
+	return res
+}
+
+// Calculate the eigenvector centrality of every vertex by power iteration,
+// the centrality of a vertex is proportional to the sum of centralities of its (in)neighbours.
+// The result is normalized to unit Euclidean length.
+// If the iteration does not converge in opts.MaxIterations steps, an error is returned.
+func EigenvectorCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	if n == 0 {
+		return map[K]float64{}, nil
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	for it := 0; it < o.MaxIterations; it++ {
+		// use A+I instead of A to avoid oscillation on bipartite graphs, the eigenvectors are the same.
+		next := append([]float64(nil), x...)
+		for v := 0; v < n; v++ {
+			for _, a := range ig.in[v] {
+				next[v] += x[a.to] * ig.arcWeight(a, o.Weighted)
+			}
+		}
+		normalizeVector(next, true)
+		var diff float64
+		for i := range x {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if diff < float64(n)*o.Tolerance {
+			return vectorToMap(ig.keys, x), nil
+		}
+	}
+	return nil, errNotConverged
+}
+
+// Calculate the Katz centrality of every vertex: x = alpha*A'x + beta,
+// the iteration converges only if alpha is less than the reciprocal of the largest eigenvalue of A.
+// If opts.Normalized is true, the result is normalized to unit Euclidean length.
+func KatzCentrality[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	x := make([]float64, n)
+	for it := 0; it < o.MaxIterations; it++ {
+		next := make([]float64, n)
+		for v := 0; v < n; v++ {
+			for _, a := range ig.in[v] {
+				next[v] += x[a.to] * ig.arcWeight(a, o.Weighted)
+			}
+			next[v] = o.Alpha*next[v] + o.Beta
+		}
+		var diff float64
+		for i := range x {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if diff < float64(n)*o.Tolerance {
+			if o.Normalized {
+				normalizeVector(x, true)
+			}
+			return vectorToMap(ig.keys, x), nil
+		}
+	}
+	return nil, errNotConverged
+}
+
+// Calculate the PageRank of every vertex, an undirected edge is treated as two opposite arcs.
+// The random surfer follows an out arc with probability opts.Damping (proportional to the arc weight if opts.Weighted),
+// otherwise jumps to a vertex chosen by opts.Personalization, dangling vertexes always jump.
+// The result sums to 1.
+func PageRank[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	if n == 0 {
+		return map[K]float64{}, nil
+	}
+	if o.Weighted && ig.hasNegativeWeight() {
+		return nil, errNegativeWeight
+	}
+	p := make([]float64, n)
+	if o.Personalization != nil {
+		for i, k := range ig.keys {
+			p[i] = o.Personalization[k]
+		}
+		normalizeVector(p, false)
+	} else {
+		for i := range p {
+			p[i] = 1 / float64(n)
+		}
+	}
+	outW := make([]float64, n)
+	for v := 0; v < n; v++ {
+		for _, a := range ig.out[v] {
+			outW[v] += ig.arcWeight(a, o.Weighted)
+		}
+	}
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1 / float64(n)
+	}
+	for it := 0; it < o.MaxIterations; it++ {
+		var dangling float64
+		for v := 0; v < n; v++ {
+			if outW[v] == 0 {
+				dangling += x[v]
+			}
+		}
+		next := make([]float64, n)
+		for v := 0; v < n; v++ {
+			if outW[v] == 0 {
+				continue
+			}
+			for _, a := range ig.out[v] {
+				next[a.to] += o.Damping * x[v] * ig.arcWeight(a, o.Weighted) / outW[v]
+			}
+		}
+		for v := 0; v < n; v++ {
+			next[v] += (1-o.Damping)*p[v] + o.Damping*dangling*p[v]
+		}
+		var diff float64
+		for i := range x {
+			diff += math.Abs(next[i] - x[i])
+		}
+		x = next
+		if diff < float64(n)*o.Tolerance {
+			return vectorToMap(ig.keys, x), nil
+		}
+	}
+	return nil, errNotConverged
+}
+
+// Calculate the hubs and authorities scores of every vertex by HITS algorithm,
+// a good hub points to many good authorities, and a good authority is pointed by many good hubs.
+// Both results sum to 1.
+func HITS[K comparable, W number](g Graph[K, W], opts *CentralityOptions[K]) (map[K]float64, map[K]float64, error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	o := opts.withDefaults()
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	if n == 0 {
+		return map[K]float64{}, map[K]float64{}, nil
+	}
+	h := make([]float64, n)
+	for i := range h {
+		h[i] = 1 / float64(n)
+	}
+	var a []float64
+	for it := 0; it < o.MaxIterations; it++ {
+		a = make([]float64, n)
+		for v := 0; v < n; v++ {
+			for _, e := range ig.in[v] {
+				a[v] += h[e.to] * ig.arcWeight(e, o.Weighted)
+			}
+		}
+		normalizeVector(a, false)
+		next := make([]float64, n)
+		for v := 0; v < n; v++ {
+			for _, e := range ig.out[v] {
+				next[v] += a[e.to] * ig.arcWeight(e, o.Weighted)
+			}
+		}
+		normalizeVector(next, false)
+		var diff float64
+		for i := range h {
+			diff += math.Abs(next[i] - h[i])
+		}
+		h = next
+		if diff < float64(n)*o.Tolerance {
+			return vectorToMap(ig.keys, h), vectorToMap(ig.keys, a), nil
+		}
+	}
+	return nil, nil, errNotConverged
+}
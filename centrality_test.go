@@ -0,0 +1,145 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func testPathGraph(n int) Graph[int, int] {
+	g := NewGraph[int, int](false, fmt.Sprintf("p_%d", n))
+	for i := 0; i < n; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i := 0; i+1 < n; i++ {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Head: i, Tail: i + 1, Weight: 1})
+	}
+	return g
+}
+
+func floatEqual(a, b float64) bool {
+	return math.Abs(a-b) < 1e-4
+}
+
+func TestBetweenness(t *testing.T) {
+	g := testPathGraph(5)
+	bc, err := BetweennessCentrality(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	expect := []float64{0, 3, 4, 3, 0}
+	for i, v := range expect {
+		if !floatEqual(bc[i], v) {
+			panic(fmt.Sprintf("betweenness of %d should be %v,but get %v", i, v, bc[i]))
+		}
+	}
+	eb, err := EdgeBetweennessCentrality(g, &CentralityOptions[int]{Weighted: true})
+	if err != nil {
+		panic(err.Error())
+	}
+	expect = []float64{4, 6, 6, 4}
+	for i, v := range expect {
+		if !floatEqual(eb[i], v) {
+			panic(fmt.Sprintf("edge betweenness of %d should be %v,but get %v", i, v, eb[i]))
+		}
+	}
+}
+
+func TestCloseness(t *testing.T) {
+	g := testPathGraph(3)
+	cc, err := ClosenessCentrality(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(cc[1], 1) || !floatEqual(cc[0], 2.0/3) {
+		panic(fmt.Sprintf("wrong closeness %v", cc))
+	}
+	hc, err := HarmonicCentrality(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(hc[1], 2) || !floatEqual(hc[0], 1.5) {
+		panic(fmt.Sprintf("wrong harmonic centrality %v", hc))
+	}
+}
+
+func TestPageRank(t *testing.T) {
+	pr, err := PageRank(Cycle(5), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	for k, v := range pr {
+		if !floatEqual(v, 0.2) {
+			panic(fmt.Sprintf("pagerank of %d should be 0.2,but get %v", k, v))
+		}
+	}
+	// star: center has higher rank and the ranks sum to 1.
+	g := NewDigraph[int, int]("")
+	for i := 0; i < 4; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i := 1; i < 4; i++ {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Tail: i, Head: 0})
+	}
+	pr, err = PageRank[int, int](g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	var sum float64
+	for _, v := range pr {
+		sum += v
+	}
+	if !floatEqual(sum, 1) || pr[0] < pr[1] || !floatEqual(pr[1], pr[2]) {
+		panic(fmt.Sprintf("wrong pagerank %v", pr))
+	}
+	// the surfer always restarts at 1, so it never visits 2 and 3.
+	pr, err = PageRank[int, int](g, &CentralityOptions[int]{Personalization: map[int]float64{1: 1}})
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(pr[2], 0) || !floatEqual(pr[0]+pr[1], 1) {
+		panic(fmt.Sprintf("wrong personalized pagerank %v", pr))
+	}
+	hubs, auth, err := HITS[int, int](g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(auth[0], 1) || !floatEqual(hubs[1], 1.0/3) {
+		panic(fmt.Sprintf("wrong hits hubs=%v authorities=%v", hubs, auth))
+	}
+}
+
+func TestEigenvectorCentrality(t *testing.T) {
+	ec, err := EigenvectorCentrality(CompleteGraph(4), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	for k, v := range ec {
+		if !floatEqual(v, 0.5) {
+			panic(fmt.Sprintf("eigenvector centrality of %d should be 0.5,but get %v", k, v))
+		}
+	}
+	kc, err := KatzCentrality(testPathGraph(3), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if kc[1] <= kc[0] || !floatEqual(kc[0], kc[2]) {
+		panic(fmt.Sprintf("wrong katz centrality %v", kc))
+	}
+}
@@ -0,0 +1,572 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "sort"
+
+// chordal records a LexBFS order of a graph and the neighbours of every vertex visited before it,
+// parent[v] is the last visited one of them (-1 if there is none).
+type chordal struct {
+	adj     [][]int
+	order   []int
+	pos     []int
+	earlier [][]int
+	parent  []int
+}
+
+func newChordal(adj [][]int) *chordal {
+	n := len(adj)
+	c := &chordal{
+		adj:     adj,
+		pos:     make([]int, n),
+		earlier: make([][]int, n),
+		parent:  make([]int, n),
+	}
+	if n > 0 {
+		c.order = lexBFSOrder(adj, 0)
+	}
+	for i, v := range c.order {
+		c.pos[v] = i
+	}
+	for v := 0; v < n; v++ {
+		c.parent[v] = -1
+		for _, u := range adj[v] {
+			if c.pos[u] < c.pos[v] {
+				c.earlier[v] = append(c.earlier[v], u)
+				if c.parent[v] < 0 || c.pos[u] > c.pos[c.parent[v]] {
+					c.parent[v] = u
+				}
+			}
+		}
+	}
+	return c
+}
+
+/*
+Tarjan and Yannakakis: a graph is chordal iff the reverse of its LexBFS order is a perfect elimination ordering,
+i.e. for every vertex v the neighbours visited before v form a clique.
+
+It is enough to check that for every vertex v, the neighbours visited before v (except its parent p)
+are also neighbours of p, this can be done in O(n+m) time.
+*/
+func (c *chordal) isChordal() bool {
+	n := len(c.adj)
+	need := make([][]int, n)
+	for v := 0; v < n; v++ {
+		if p := c.parent[v]; p >= 0 {
+			for _, u := range c.earlier[v] {
+				if u != p {
+					need[p] = append(need[p], u)
+				}
+			}
+		}
+	}
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for p := 0; p < n; p++ {
+		for _, u := range c.adj[p] {
+			mark[u] = p
+		}
+		for _, u := range need[p] {
+			if mark[u] != p {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// maximalCliques returns the maximal cliques of a chordal graph, which are among the cliques {v} ∪ earlier(v).
+// The clique of p is not maximal iff there is a vertex v with parent p and |earlier(v)| = |earlier(p)|+1.
+func (c *chordal) maximalCliques() [][]int {
+	n := len(c.adj)
+	maximal := make([]bool, n)
+	for v := 0; v < n; v++ {
+		maximal[v] = true
+	}
+	for v := 0; v < n; v++ {
+		if p := c.parent[v]; p >= 0 && len(c.earlier[v]) == len(c.earlier[p])+1 {
+			maximal[p] = false
+		}
+	}
+	var res [][]int
+	for _, v := range c.order {
+		if maximal[v] {
+			res = append(res, append([]int{v}, c.earlier[v]...))
+		}
+	}
+	return res
+}
+
+func newChordalOf[K comparable, W number](g Graph[K, W]) (*indexedGraph[K, W], *chordal, error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	return ig, newChordal(ig.simpleNeighbours()), nil
+}
+
+// Determine whether the graph is chordal, i.e. every cycle of length at least 4 has a chord.
+// The direction of arcs, loops and multiple edges are ignored.
+func IsChordal[K comparable, W number](g Graph[K, W]) (bool, error) {
+	_, c, err := newChordalOf(g)
+	if err != nil {
+		return false, err
+	}
+	return c.isChordal(), nil
+}
+
+// Calculate a perfect elimination ordering of a chordal graph, i.e. an order of vertexes such that
+// for every vertex v, the neighbours of v after v in the order form a clique.
+// The order is the reverse of a LexBFS order, if the graph is not chordal an error will be returned.
+func PerfectEliminationOrdering[K comparable, W number](g Graph[K, W]) ([]K, error) {
+	ig, c, err := newChordalOf(g)
+	if err != nil {
+		return nil, err
+	}
+	if !c.isChordal() {
+		return nil, errNotChordal
+	}
+	res := make([]K, len(c.order))
+	for i, v := range c.order {
+		res[len(res)-1-i] = ig.keys[v]
+	}
+	return res, nil
+}
+
+// Calculate all the maximal cliques of a chordal graph in O(n+m) time, a chordal graph has at most n maximal cliques.
+// If the graph is not chordal an error will be returned.
+func MaximalCliquesChordal[K comparable, W number](g Graph[K, W]) ([][]K, error) {
+	ig, c, err := newChordalOf(g)
+	if err != nil {
+		return nil, err
+	}
+	if !c.isChordal() {
+		return nil, errNotChordal
+	}
+	var res [][]K
+	for _, cl := range c.maximalCliques() {
+		ks := make([]K, len(cl))
+		for i, v := range cl {
+			ks[i] = ig.keys[v]
+		}
+		res = append(res, ks)
+	}
+	return res, nil
+}
+
+/*
+MCS-M algorithm (Berry, Blair, Heggernes and Peyton), compute a minimal triangulation in O(nm) time.
+
+All the vertexes have weight 0 at the beginning, and vertexes are numbered from n to 1:
+
+	for i = n to 1:
+	    choose an unnumbered vertex v of maximum weight
+	    for every unnumbered vertex u, if there is a path v,x1,...,xk,u through unnumbered vertexes
+	    with w(xj) < w(u) for all j:
+	        w(u) = w(u) + 1 and add the fill edge (v,u) if u and v are not adjacent
+	    number v by i
+
+For every step a bottleneck search is used, the bottleneck of u is the minimum of
+max{ w(xj) } over all paths from v to u, and u is reached iff its bottleneck is less than w(u).
+*/
+func minimalTriangulation(adj [][]int) [][2]int {
+	n := len(adj)
+	weight := make([]int, n)
+	numbered := make([]bool, n)
+	bottleneck := make([]int, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	var fill [][2]int
+	for i := n - 1; i >= 0; i-- {
+		v := -1
+		for u := 0; u < n; u++ {
+			if !numbered[u] && (v < 0 || weight[u] > weight[v]) {
+				v = u
+			}
+		}
+		numbered[v] = true
+		for u := range bottleneck {
+			bottleneck[u] = n + 1
+		}
+		for _, u := range adj[v] {
+			mark[u] = v
+		}
+		// buckets[b] contains the vertexes with bottleneck b (b = -1 is stored in buckets[0]).
+		buckets := make([][]int, n+2)
+		for _, u := range adj[v] {
+			if !numbered[u] {
+				bottleneck[u] = -1
+				buckets[0] = append(buckets[0], u)
+			}
+		}
+		var reached []int
+		done := make([]bool, n)
+		for b := 0; b < len(buckets); b++ {
+			for len(buckets[b]) > 0 {
+				u := buckets[b][len(buckets[b])-1]
+				buckets[b] = buckets[b][:len(buckets[b])-1]
+				if done[u] || bottleneck[u]+1 != b {
+					continue
+				}
+				done[u] = true
+				if bottleneck[u] < weight[u] {
+					reached = append(reached, u)
+				}
+				nb := max(bottleneck[u], weight[u])
+				for _, x := range adj[u] {
+					if x != v && !numbered[x] && !done[x] && nb < bottleneck[x] {
+						bottleneck[x] = nb
+						buckets[nb+1] = append(buckets[nb+1], x)
+					}
+				}
+			}
+		}
+		for _, u := range reached {
+			weight[u]++
+			if mark[u] != v {
+				fill = append(fill, [2]int{v, u})
+			}
+		}
+	}
+	return fill
+}
+
+// Calculate a minimal triangulation (chordal completion) of an undirected graph,
+// i.e. a chordal supergraph obtained by adding a set of fill edges such that no proper subset of it gives a chordal graph.
+// Return a new graph which contains g and the fill edges, and the fill edges.
+// The key of a fill edge is generated by newEdgeKey if it is not nil, otherwise the edge format of two endpoints is tried.
+func ChordalCompletion[K comparable, W number](g Graph[K, W], newEdgeKey func(Edge[K, W]) K) (Graph[K, W], []Edge[K, W], error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	if g.IsDigraph() {
+		return nil, nil, errDigraph
+	}
+	ig := newIndexedGraph(g)
+	h, err := g.Clone()
+	if err != nil {
+		return nil, nil, err
+	}
+	var fill []Edge[K, W]
+	for _, f := range minimalTriangulation(ig.simpleNeighbours()) {
+		e := Edge[K, W]{Tail: ig.keys[f[0]], Head: ig.keys[f[1]]}
+		if newEdgeKey != nil {
+			e.Key = newEdgeKey(e)
+			if err := h.AddEdge(e); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			added := false
+			for i := 0; i < 50 && !added; i++ {
+				e.Key = edgeFormat(e.Tail, e.Head)
+				if err := h.AddEdge(e); err != nil {
+					if IsAlreadyExists(err) {
+						continue
+					}
+					return nil, nil, err
+				}
+				added = true
+			}
+			if !added {
+				return nil, nil, errEdgeExists
+			}
+		}
+		fill = append(fill, e)
+	}
+	return h, fill, nil
+}
+
+// splitNumber returns the vertexes sorted by degree in descending order, and the number m of the
+// largest degrees d1 >= ... >= dm such that di >= i-1.
+func splitNumber(adj [][]int) ([]int, int) {
+	vs := make([]int, len(adj))
+	for i := range vs {
+		vs[i] = i
+	}
+	sort.SliceStable(vs, func(i, j int) bool { return len(adj[vs[i]]) > len(adj[vs[j]]) })
+	m := 0
+	for i, v := range vs {
+		if len(adj[v]) >= i {
+			m = i + 1
+		}
+	}
+	return vs, m
+}
+
+/*
+Hammer and Simeone: let d1 >= d2 >= ... >= dn be the degree sequence of the graph and m = max{ i | di >= i-1 },
+the graph is split iff
+
+	d1 + ... + dm = m(m-1) + d(m+1) + ... + dn
+
+and if so, the m vertexes with largest degree form a clique and the others form an independent set.
+*/
+func splitPartition(adj [][]int) ([]int, []int, bool) {
+	vs, m := splitNumber(adj)
+	left, right := 0, 0
+	for i, v := range vs {
+		if i < m {
+			left += len(adj[v])
+		} else {
+			right += len(adj[v])
+		}
+	}
+	if left != m*(m-1)+right {
+		return nil, nil, false
+	}
+	return vs[:m], vs[m:], true
+}
+
+// Determine whether the graph is a split graph, i.e. the vertexes can be partitioned into a clique and an independent set.
+// The direction of arcs, loops and multiple edges are ignored.
+func IsSplitGraph[K comparable, W number](g Graph[K, W]) (bool, error) {
+	if g == nil {
+		return false, errNilGraph
+	}
+	_, _, ok := splitPartition(newIndexedGraph(g).simpleNeighbours())
+	return ok, nil
+}
+
+// Calculate a partition of a split graph into a clique and an independent set,
+// if the graph is not split an error will be returned.
+func SplitPartition[K comparable, W number](g Graph[K, W]) ([]K, []K, error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	clique, independent, ok := splitPartition(ig.simpleNeighbours())
+	if !ok {
+		return nil, nil, errNotSplit
+	}
+	cs, is := make([]K, len(clique)), make([]K, len(independent))
+	for i, v := range clique {
+		cs[i] = ig.keys[v]
+	}
+	for i, v := range independent {
+		is[i] = ig.keys[v]
+	}
+	return cs, is, nil
+}
+
+// cliqueClass is a class of the ordered partition of maximal cliques,
+// the classes form a doubly linked list, label increases along the list.
+type cliqueClass struct {
+	members    []int
+	prev, next int
+	label      int
+}
+
+// cliquePartition is an ordered partition of the maximal cliques refined by the cliques of vertexes.
+type cliquePartition struct {
+	cliques [][]int
+	in      [][]int // in[v] is the cliques containing v
+	classes []cliqueClass
+	classOf []int
+	pos     []int // position of a clique in the members of its class
+	head    int
+	pivots  []int
+	// a vertex is settled if its cliques are a run of whole classes, which is kept by further refinement.
+	settled []bool
+	stamp   []int
+}
+
+const cliqueLabelGap = 1 << 20
+
+func newCliquePartition(n int, cliques [][]int) *cliquePartition {
+	p := &cliquePartition{
+		cliques: cliques,
+		in:      make([][]int, n),
+		classOf: make([]int, len(cliques)),
+		pos:     make([]int, len(cliques)),
+		settled: make([]bool, n),
+		stamp:   make([]int, n),
+	}
+	all := make([]int, len(cliques))
+	for i, cl := range cliques {
+		all[i] = i
+		p.pos[i] = i
+		for _, v := range cl {
+			p.in[v] = append(p.in[v], i)
+		}
+	}
+	p.classes = append(p.classes, cliqueClass{members: all, prev: -1, next: -1})
+	return p
+}
+
+// relabel the classes along the list.
+func (p *cliquePartition) relabel() {
+	l := 0
+	for x := p.head; x >= 0; x = p.classes[x].next {
+		p.classes[x].label = l
+		l += cliqueLabelGap
+	}
+}
+
+// Move the cliques s (a proper subset of class x) to a new class placed before (or after) x.
+// The vertexes of the smaller part become pivots, since only their cliques can meet both parts.
+func (p *cliquePartition) split(x int, s []int, before bool) {
+	if len(s) == 0 || len(s) == len(p.classes[x].members) {
+		return
+	}
+	y := len(p.classes)
+	p.classes = append(p.classes, cliqueClass{prev: -1, next: -1})
+	for _, c := range s {
+		ms := p.classes[x].members
+		i, last := p.pos[c], ms[len(ms)-1]
+		ms[i], p.pos[last] = last, i
+		p.classes[x].members = ms[:len(ms)-1]
+		p.pos[c] = len(p.classes[y].members)
+		p.classes[y].members = append(p.classes[y].members, c)
+		p.classOf[c] = y
+	}
+	// link y before or after x.
+	a, b := p.classes[x].prev, x
+	if !before {
+		a, b = x, p.classes[x].next
+	}
+	p.classes[y].prev, p.classes[y].next = a, b
+	if a >= 0 {
+		p.classes[a].next = y
+	} else {
+		p.head = y
+	}
+	if b >= 0 {
+		p.classes[b].prev = y
+	}
+	lo, hi := p.classes[x].label-2*cliqueLabelGap, p.classes[x].label+2*cliqueLabelGap
+	if a >= 0 {
+		lo = p.classes[a].label
+	}
+	if b >= 0 {
+		hi = p.classes[b].label
+	}
+	if hi-lo < 2 {
+		p.relabel()
+	} else {
+		p.classes[y].label = lo + (hi-lo)/2
+	}
+	small := y
+	if len(p.classes[x].members) < len(p.classes[y].members) {
+		small = x
+	}
+	for _, c := range p.classes[small].members {
+		for _, v := range p.cliques[c] {
+			if !p.settled[v] && p.stamp[v] != y+1 {
+				p.stamp[v] = y + 1
+				p.pivots = append(p.pivots, v)
+			}
+		}
+	}
+}
+
+// Refine the partition by the cliques of the pivot v: the cliques containing v must be consecutive,
+// so in the first class (and the last class) meeting them, they are moved to the end (and the beginning).
+func (p *cliquePartition) refine(v int) {
+	first, last := -1, -1
+	for _, c := range p.in[v] {
+		x := p.classOf[c]
+		if first < 0 || p.classes[x].label < p.classes[first].label {
+			first = x
+		}
+		if last < 0 || p.classes[x].label > p.classes[last].label {
+			last = x
+		}
+	}
+	if first == last {
+		return
+	}
+	p.settled[v] = true
+	var a, b []int
+	for _, c := range p.in[v] {
+		switch p.classOf[c] {
+		case first:
+			a = append(a, c)
+		case last:
+			b = append(b, c)
+		}
+	}
+	p.split(first, a, false)
+	p.split(last, b, true)
+}
+
+/*
+A graph is an interval graph iff it is chordal and its maximal cliques can be ordered such that
+the cliques containing any vertex are consecutive, i.e. it has a clique path (Gilmore and Hoffman).
+
+The maximal cliques are found from the LexBFS order, and ordered by partition refinement
+(Habib, McConnell, Paul and Viennot): starting with one class of all cliques,
+the cliques containing a pivot vertex are pulled together, and if there is no pivot,
+the last clique discovered by LexBFS in a non-singleton class is separated from the class as an end clique.
+Finally the clique order is checked.
+Every clique is moved to a smaller part O(log(n)) times, so the refinement takes O((n+m)log(n)) time.
+*/
+func isInterval(adj [][]int) bool {
+	c := newChordal(adj)
+	if !c.isChordal() {
+		return false
+	}
+	cliques := c.maximalCliques()
+	if len(cliques) <= 2 {
+		return true
+	}
+	p := newCliquePartition(len(adj), cliques)
+	// the cliques are listed in the order of discovery, and a clique in a singleton class stays there.
+	for last := len(cliques) - 1; ; {
+		for len(p.pivots) > 0 {
+			v := p.pivots[len(p.pivots)-1]
+			p.pivots = p.pivots[:len(p.pivots)-1]
+			p.refine(v)
+		}
+		for last >= 0 && len(p.classes[p.classOf[last]].members) == 1 {
+			last--
+		}
+		if last < 0 {
+			break
+		}
+		p.split(p.classOf[last], []int{last}, false)
+	}
+	order := make([]int, len(cliques))
+	i := 0
+	for x := p.head; x >= 0; x = p.classes[x].next {
+		order[p.classes[x].members[0]] = i
+		i++
+	}
+	for _, cs := range p.in {
+		lo, hi := len(cliques), -1
+		for _, cl := range cs {
+			lo, hi = min(lo, order[cl]), max(hi, order[cl])
+		}
+		if hi-lo+1 > len(cs) {
+			return false
+		}
+	}
+	return true
+}
+
+// Determine whether the graph is an interval graph, i.e. the intersection graph of a family of intervals on the real line.
+// The direction of arcs, loops and multiple edges are ignored.
+func IsIntervalGraph[K comparable, W number](g Graph[K, W]) (bool, error) {
+	if g == nil {
+		return false, errNilGraph
+	}
+	return isInterval(newIndexedGraph(g).simpleNeighbours()), nil
+}
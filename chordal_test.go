@@ -0,0 +1,339 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func testRandomGraph(r *rand.Rand, n int, p float64) Graph[int, int] {
+	var es [][3]int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			if r.Float64() < p {
+				es = append(es, [3]int{i, j, 1})
+			}
+		}
+	}
+	return testMultigraph(false, n, es)
+}
+
+func testAdjacent(g Graph[int, int], u, v int) bool {
+	es, err := g.GetEdge(u, v)
+	return err == nil && len(es) > 0
+}
+
+// bruteChordal removes simplicial vertexes until the graph is empty.
+func bruteChordal(g Graph[int, int]) bool {
+	alive := make(map[int]bool)
+	for _, v := range g.AllVertexes() {
+		alive[v.Key] = true
+	}
+	for len(alive) > 0 {
+		found := false
+		for v := range alive {
+			var ns []int
+			for u := range alive {
+				if u != v && testAdjacent(g, u, v) {
+					ns = append(ns, u)
+				}
+			}
+			simplicial := true
+			for i := 0; i < len(ns) && simplicial; i++ {
+				for j := i + 1; j < len(ns); j++ {
+					if !testAdjacent(g, ns[i], ns[j]) {
+						simplicial = false
+						break
+					}
+				}
+			}
+			if simplicial {
+				delete(alive, v)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// bruteSplit tries every subset as the clique.
+func bruteSplit(g Graph[int, int]) bool {
+	n := g.Order()
+	for s := 0; s < 1<<n; s++ {
+		ok := true
+		for u := 0; u < n && ok; u++ {
+			for v := u + 1; v < n; v++ {
+				in := s&(1<<u) != 0
+				if in == (s&(1<<v) != 0) && in != testAdjacent(g, u, v) {
+					ok = false
+					break
+				}
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func TestChordal(t *testing.T) {
+	r := rand.New(rand.NewSource(11))
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(8)
+		g := testRandomGraph(r, n, r.Float64())
+		ok, err := IsChordal(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if ok != bruteChordal(g) {
+			panic(fmt.Sprintf("wrong chordality %v of %v", ok, g.AllEdges()))
+		}
+		if split, _ := IsSplitGraph(g); split != bruteSplit(g) {
+			panic(fmt.Sprintf("wrong split recognition %v of %v", split, g.AllEdges()))
+		}
+		if !ok {
+			if _, err = PerfectEliminationOrdering(g); err != errNotChordal {
+				panic("graph is not chordal")
+			}
+			h, fill, err := ChordalCompletion(g, nil)
+			if err != nil {
+				panic(err.Error())
+			}
+			if !bruteChordal(h) || len(fill) == 0 {
+				panic(fmt.Sprintf("completion of %v is not chordal", g.AllEdges()))
+			}
+			// a triangulation is minimal iff removing any single fill edge breaks chordality.
+			for _, e := range fill {
+				c, _ := h.Clone()
+				if err = c.RemoveEdgeByKey(e.Key); err != nil {
+					panic(err.Error())
+				}
+				if bruteChordal(c) {
+					panic(fmt.Sprintf("completion of %v is not minimal, %v", g.AllEdges(), fill))
+				}
+			}
+			continue
+		}
+		peo, err := PerfectEliminationOrdering(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		pos := make(map[int]int)
+		for i, v := range peo {
+			pos[v] = i
+		}
+		for _, v := range peo {
+			var later []int
+			for u := range pos {
+				if pos[u] > pos[v] && testAdjacent(g, u, v) {
+					later = append(later, u)
+				}
+			}
+			for i := range later {
+				for j := i + 1; j < len(later); j++ {
+					if !testAdjacent(g, later[i], later[j]) {
+						panic(fmt.Sprintf("wrong perfect elimination ordering %v", peo))
+					}
+				}
+			}
+		}
+		cliques, err := MaximalCliquesChordal(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		// every clique is maximal, and every edge is covered by some clique.
+		covered := make(map[[2]int]bool)
+		for _, c := range cliques {
+			for i := range c {
+				for j := range c {
+					if i != j && !testAdjacent(g, c[i], c[j]) {
+						panic(fmt.Sprintf("%v is not a clique", c))
+					}
+					covered[[2]int{c[i], c[j]}] = true
+				}
+			}
+			for v := 0; v < n; v++ {
+				all := true
+				for _, u := range c {
+					if u == v || !testAdjacent(g, u, v) {
+						all = false
+						break
+					}
+				}
+				if all {
+					panic(fmt.Sprintf("clique %v is not maximal", c))
+				}
+			}
+		}
+		for _, e := range g.AllEdges() {
+			if !covered[[2]int{e.Head, e.Tail}] {
+				panic(fmt.Sprintf("edge %v is not covered by cliques %v", e, cliques))
+			}
+		}
+	}
+	fmt.Println("=======> test chordal pass")
+}
+
+func TestIntervalGraph(t *testing.T) {
+	// C4 is not chordal, and the subdivided claw has an asteroidal triple.
+	for _, es := range [][][3]int{
+		{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}},
+		{{0, 1, 1}, {1, 2, 1}, {0, 3, 1}, {3, 4, 1}, {0, 5, 1}, {5, 6, 1}},
+	} {
+		if ok, err := IsIntervalGraph(testMultigraph(false, 7, es)); err != nil || ok {
+			panic(fmt.Sprintf("%v is not interval graph, %v", es, err))
+		}
+	}
+	r := rand.New(rand.NewSource(13))
+	for round := 0; round < 50; round++ {
+		n := 2 + r.Intn(10)
+		ls, rs := make([]int, n), make([]int, n)
+		for i := 0; i < n; i++ {
+			ls[i] = r.Intn(20)
+			rs[i] = ls[i] + r.Intn(6)
+		}
+		var es [][3]int
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if ls[i] <= rs[j] && ls[j] <= rs[i] {
+					es = append(es, [3]int{i, j, 1})
+				}
+			}
+		}
+		if ok, err := IsIntervalGraph(testMultigraph(false, n, es)); err != nil || !ok {
+			panic(fmt.Sprintf("%v is interval graph, %v", es, err))
+		}
+	}
+	var visited []int
+	g := testPetersen()
+	err := LexBFS(g, 7, func(v Vertex[int, int]) error {
+		visited = append(visited, v.Key)
+		return nil
+	})
+	if err != nil || len(visited) != 10 || visited[0] != 7 {
+		panic(fmt.Sprintf("wrong lexbfs order %v, %v", visited, err))
+	}
+	fmt.Println("=======> test interval graph pass")
+}
+
+// bruteInterval is the test of Lekkerkerker and Boland: a graph is an interval graph iff it is chordal
+// and has no asteroidal triple, i.e. three vertexes such that every two of them are connected by a path
+// avoiding the neighbourhood of the third one.
+func bruteInterval(adj [][]int) bool {
+	if !newChordal(adj).isChordal() {
+		return false
+	}
+	n := len(adj)
+	comp := make([][]int, n)
+	for v := 0; v < n; v++ {
+		comp[v] = make([]int, n)
+		for _, u := range adj[v] {
+			comp[v][u] = -1
+		}
+		comp[v][v] = -1
+		// component ids start from 1, 0 means not visited.
+		id := 0
+		for s := 0; s < n; s++ {
+			if comp[v][s] != 0 {
+				continue
+			}
+			id++
+			comp[v][s] = id
+			stk := []int{s}
+			for len(stk) > 0 {
+				x := stk[len(stk)-1]
+				stk = stk[:len(stk)-1]
+				for _, y := range adj[x] {
+					if comp[v][y] == 0 {
+						comp[v][y] = id
+						stk = append(stk, y)
+					}
+				}
+			}
+		}
+	}
+	for a := 0; a < n; a++ {
+		for b := a + 1; b < n; b++ {
+			if comp[a][b] < 0 {
+				continue
+			}
+			for c := b + 1; c < n; c++ {
+				if comp[a][c] < 0 || comp[b][c] < 0 {
+					continue
+				}
+				if comp[a][b] == comp[a][c] && comp[b][a] == comp[b][c] && comp[c][a] == comp[c][b] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+func TestIntervalGraphRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(40))
+	for round := 0; round < 3000; round++ {
+		n := 3 + r.Intn(12)
+		var g Graph[int, int]
+		if round%2 == 0 {
+			g = testRandomGraph(r, n, r.Float64())
+		} else {
+			// intersection graph of subtrees of a random tree is chordal, but not always interval.
+			m := 2 + r.Intn(8)
+			parent := make([]int, m)
+			for i := 1; i < m; i++ {
+				parent[i] = r.Intn(i)
+			}
+			subtrees := make([]map[int]bool, n)
+			for i := range subtrees {
+				x := r.Intn(m)
+				subtrees[i] = map[int]bool{x: true}
+				for s := r.Intn(4); s > 0 && x > 0; s-- {
+					x = parent[x]
+					subtrees[i][x] = true
+				}
+			}
+			var es [][3]int
+			for i := 0; i < n; i++ {
+				for j := i + 1; j < n; j++ {
+					for x := range subtrees[i] {
+						if subtrees[j][x] {
+							es = append(es, [3]int{i, j, 1})
+							break
+						}
+					}
+				}
+			}
+			g = testMultigraph(false, n, es)
+		}
+		ok, err := IsIntervalGraph(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if want := bruteInterval(newIndexedGraph(g).simpleNeighbours()); ok != want {
+			panic(fmt.Sprintf("interval graph of %v should be %v,but get %v", g.AllEdges(), want, ok))
+		}
+	}
+	fmt.Println("=======> test interval graph recognition pass")
+}
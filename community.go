@@ -0,0 +1,400 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math/rand"
+	"sort"
+)
+
+// communityGraph is a weighted undirected graph used by community detection,
+// adj[i] records the weight between i and its neighbours, self[i] records the weight of loops on i.
+type communityGraph struct {
+	adj    []map[int]float64
+	self   []float64
+	degree []float64
+	m2     float64 // 2 * total weight
+}
+
+// Build the weighted undirected graph of g, the direction of arcs is ignored.
+// Edges are weighted by Edge.Weight, but if all edge weights are zero (i.e. the graph is unweighted),
+// every edge is treated as weight 1.
+func newCommunityGraph[K comparable, W number](ig *indexedGraph[K, W]) (*communityGraph, error) {
+	n := ig.order()
+	cg := &communityGraph{
+		adj:    make([]map[int]float64, n),
+		self:   make([]float64, n),
+		degree: make([]float64, n),
+	}
+	for i := range cg.adj {
+		cg.adj[i] = make(map[int]float64)
+	}
+	unweighted := true
+	for _, e := range ig.edges {
+		if e.Weight < 0 {
+			return nil, errNegativeWeight
+		}
+		if e.Weight != 0 {
+			unweighted = false
+		}
+	}
+	for _, e := range ig.edges {
+		t, h, w := ig.idx[e.Tail], ig.idx[e.Head], float64(e.Weight)
+		if unweighted {
+			w = 1
+		}
+		cg.addWeight(t, h, w)
+	}
+	return cg, nil
+}
+
+func (cg *communityGraph) addWeight(u, v int, w float64) {
+	if u == v {
+		cg.self[u] += w
+		cg.degree[u] += 2 * w
+	} else {
+		cg.adj[u][v] += w
+		cg.adj[v][u] += w
+		cg.degree[u] += w
+		cg.degree[v] += w
+	}
+	cg.m2 += 2 * w
+}
+
+func (cg *communityGraph) order() int {
+	return len(cg.adj)
+}
+
+// Move every vertex to the neighbouring community with the maximum modularity gain until no vertex moves,
+// return the community of every vertex (numbered from 0) and whether any vertex moved.
+func (cg *communityGraph) localMoving(resolution float64) ([]int, bool) {
+	n := cg.order()
+	comm := make([]int, n)
+	tot := make([]float64, n)
+	for i := 0; i < n; i++ {
+		comm[i] = i
+		tot[i] = cg.degree[i]
+	}
+	var moved bool
+	for improved := true; improved; {
+		improved = false
+		for i := 0; i < n; i++ {
+			ci, ki := comm[i], cg.degree[i]
+			// weights from i to neighbouring communities.
+			links := make(map[int]float64)
+			var cs []int
+			for j, w := range cg.adj[i] {
+				if _, ok := links[comm[j]]; !ok {
+					cs = append(cs, comm[j])
+				}
+				links[comm[j]] += w
+			}
+			sort.Ints(cs)
+			tot[ci] -= ki
+			// gain of moving i into community c (up to a constant factor):
+			//     k_i,in - resolution * tot_c * k_i / 2m
+			best, bestGain := ci, links[ci]-resolution*tot[ci]*ki/cg.m2
+			for _, c := range cs {
+				if gain := links[c] - resolution*tot[c]*ki/cg.m2; gain > bestGain+1e-12 {
+					best, bestGain = c, gain
+				}
+			}
+			tot[best] += ki
+			if best != ci {
+				comm[i] = best
+				improved, moved = true, true
+			}
+		}
+	}
+	return renumber(comm), moved
+}
+
+// renumber communities to 0..k-1 in order of first appearance.
+func renumber(comm []int) []int {
+	idx := make(map[int]int)
+	res := make([]int, len(comm))
+	for i, c := range comm {
+		j, ok := idx[c]
+		if !ok {
+			j = len(idx)
+			idx[c] = j
+		}
+		res[i] = j
+	}
+	return res
+}
+
+// Aggregate every community into a single vertex.
+func (cg *communityGraph) aggregate(comm []int) *communityGraph {
+	k := 0
+	for _, c := range comm {
+		k = max(k, c+1)
+	}
+	ng := &communityGraph{
+		adj:    make([]map[int]float64, k),
+		self:   make([]float64, k),
+		degree: make([]float64, k),
+	}
+	for i := range ng.adj {
+		ng.adj[i] = make(map[int]float64)
+	}
+	for u := 0; u < cg.order(); u++ {
+		ng.addWeight(comm[u], comm[u], cg.self[u])
+		for v, w := range cg.adj[u] {
+			if u < v {
+				ng.addWeight(comm[u], comm[v], w)
+			}
+		}
+	}
+	return ng
+}
+
+func groupCommunities[K comparable](keys []K, comm []int) [][]K {
+	k := 0
+	for _, c := range comm {
+		k = max(k, c+1)
+	}
+	res := make([][]K, k)
+	for i, c := range comm {
+		res[c] = append(res[c], keys[i])
+	}
+	return res
+}
+
+// Build the quotient graph of g with respect to the partition:
+// every community is represented by a vertex whose key is the key of its first member and value is the community,
+// and there is an edge between two communities if there are edges between their members,
+// the key of the edge is the key of one of these edges and the weight is the sum of their weights.
+// If g is a digraph, the quotient graph is a digraph as well.
+func quotientGraph[K comparable, W number](g Graph[K, W], communities [][]K) (Graph[K, W], error) {
+	q := NewGraph[K, W](g.IsDigraph(), g.Name()+"_quotient")
+	root := make(map[K]K)
+	for _, c := range communities {
+		if len(c) == 0 {
+			continue
+		}
+		if err := q.AddVertex(Vertex[K, W]{Key: c[0], Value: c}); err != nil {
+			return nil, err
+		}
+		for _, v := range c {
+			root[v] = c[0]
+		}
+	}
+	type pair struct{ tail, head K }
+	edges := make(map[pair]*Edge[K, W])
+	var order []pair
+	for _, e := range g.AllEdges() {
+		t, h := root[e.Tail], root[e.Head]
+		if t == h {
+			continue
+		}
+		p := pair{tail: t, head: h}
+		if _, ok := edges[p]; !ok && !g.IsDigraph() {
+			if _, ok := edges[pair{tail: h, head: t}]; ok {
+				p = pair{tail: h, head: t}
+			}
+		}
+		if qe, ok := edges[p]; ok {
+			qe.Weight += e.Weight
+			continue
+		}
+		edges[p] = &Edge[K, W]{Key: e.Key, Tail: p.tail, Head: p.head, Weight: e.Weight}
+		order = append(order, p)
+	}
+	for _, p := range order {
+		if err := q.AddEdge(*edges[p]); err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+// Detect communities by Louvain algorithm, which greedily optimizes the modularity:
+// first every vertex is moved to the neighbouring community with the maximum modularity gain until no improvement,
+// then every community is aggregated into a single vertex, and the two phases are repeated on the aggregated graph.
+// The resolution parameter (usually 1) controls the size of communities, a larger resolution gives smaller communities.
+// The direction of arcs is ignored. Edges are weighted by Edge.Weight, but if all edge weights are zero,
+// every edge is treated as weight 1.
+// If quotient is true, the quotient graph (every community is contracted into a vertex) is returned as well.
+func Louvain[K comparable, W number](g Graph[K, W], resolution float64, quotient bool) ([][]K, Graph[K, W], error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	cg, err := newCommunityGraph(ig)
+	if err != nil {
+		return nil, nil, err
+	}
+	membership := make([]int, ig.order())
+	for i := range membership {
+		membership[i] = i
+	}
+	if cg.m2 > 0 {
+		for {
+			comm, moved := cg.localMoving(resolution)
+			if !moved {
+				break
+			}
+			for i, c := range membership {
+				membership[i] = comm[c]
+			}
+			cg = cg.aggregate(comm)
+		}
+	}
+	communities := groupCommunities(ig.keys, renumber(membership))
+	if !quotient {
+		return communities, nil, nil
+	}
+	q, err := quotientGraph(g, communities)
+	if err != nil {
+		return nil, nil, err
+	}
+	return communities, q, nil
+}
+
+// Detect communities by asynchronous label propagation: initially every vertex has a unique label,
+// then every vertex (in random order) adopts the label with the maximum total weight among its neighbours,
+// until every vertex has a label that the maximum number of its neighbours have.
+// The direction of arcs is ignored. Edges are weighted by Edge.Weight, but if all edge weights are zero,
+// every edge is treated as weight 1.
+// If quotient is true, the quotient graph (every community is contracted into a vertex) is returned as well.
+func LabelPropagation[K comparable, W number](g Graph[K, W], quotient bool) ([][]K, Graph[K, W], error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	cg, err := newCommunityGraph(ig)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := cg.order()
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = i
+	}
+	// the label of v is stable if it's one of the most frequent labels among its neighbours.
+	bestLabels := func(v int) ([]int, bool) {
+		ws := make(map[int]float64)
+		for u, w := range cg.adj[v] {
+			ws[labels[u]] += w
+		}
+		var best []int
+		var bw float64
+		for l, w := range ws {
+			if w > bw+1e-12 {
+				best, bw = []int{l}, w
+			} else if w > bw-1e-12 {
+				best = append(best, l)
+			}
+		}
+		stable := len(best) == 0
+		for _, l := range best {
+			if l == labels[v] {
+				stable = true
+			}
+		}
+		sort.Ints(best)
+		return best, stable
+	}
+	const maxIterations = 100
+	for it := 0; it < maxIterations; it++ {
+		for _, v := range rand.Perm(n) {
+			if best, stable := bestLabels(v); !stable {
+				labels[v] = best[rand.Intn(len(best))]
+			}
+		}
+		done := true
+		for v := 0; v < n; v++ {
+			if _, stable := bestLabels(v); !stable {
+				done = false
+				break
+			}
+		}
+		if done {
+			break
+		}
+	}
+	communities := groupCommunities(ig.keys, renumber(labels))
+	if !quotient {
+		return communities, nil, nil
+	}
+	q, err := quotientGraph(g, communities)
+	if err != nil {
+		return nil, nil, err
+	}
+	return communities, q, nil
+}
+
+// Calculate the modularity of a partition of graph g:
+//
+//	Q = sum_c ( L_c/m - resolution * (d_c/2m)^2 )
+//
+// where m is the total weight of edges, L_c is the total weight of edges inside community c,
+// and d_c is the sum of degrees of vertexes in c.
+// Every vertex must belong to exactly one community of the partition.
+// The direction of arcs is ignored. Edges are weighted by Edge.Weight, but if all edge weights are zero,
+// every edge is treated as weight 1.
+func Modularity[K comparable, W number](g Graph[K, W], partition [][]K, resolution float64) (float64, error) {
+	if g == nil {
+		return 0, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	cg, err := newCommunityGraph(ig)
+	if err != nil {
+		return 0, err
+	}
+	comm := make([]int, ig.order())
+	for i := range comm {
+		comm[i] = -1
+	}
+	for c, vs := range partition {
+		for _, v := range vs {
+			i, ok := ig.idx[v]
+			if !ok {
+				return 0, errVertexNotExists
+			}
+			if comm[i] != -1 {
+				return 0, errInvalidPartition
+			}
+			comm[i] = c
+		}
+	}
+	for _, c := range comm {
+		if c == -1 {
+			return 0, errInvalidPartition
+		}
+	}
+	if cg.m2 == 0 {
+		return 0, nil
+	}
+	inner := make([]float64, len(partition))
+	tot := make([]float64, len(partition))
+	for u := 0; u < cg.order(); u++ {
+		tot[comm[u]] += cg.degree[u]
+		inner[comm[u]] += 2 * cg.self[u]
+		for v, w := range cg.adj[u] {
+			if comm[u] == comm[v] {
+				inner[comm[u]] += w
+			}
+		}
+	}
+	var q float64
+	for c := range partition {
+		q += inner[c]/cg.m2 - resolution*(tot[c]/cg.m2)*(tot[c]/cg.m2)
+	}
+	return q, nil
+}
@@ -0,0 +1,81 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+// two K4 connected by edge 3-4.
+func testTwoCliques() Graph[int, int] {
+	g := NewGraph[int, int](false, "two-cliques")
+	for i := 0; i < 8; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	var k int
+	for _, base := range []int{0, 4} {
+		for i := 0; i < 4; i++ {
+			for j := i + 1; j < 4; j++ {
+				_ = g.AddEdge(Edge[int, int]{Key: k, Head: base + i, Tail: base + j})
+				k++
+			}
+		}
+	}
+	_ = g.AddEdge(Edge[int, int]{Key: k, Head: 3, Tail: 4})
+	return g
+}
+
+func TestLouvain(t *testing.T) {
+	g := testTwoCliques()
+	cs, q, err := Louvain(g, 1.0, true)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(cs) != 2 || len(cs[0]) != 4 || len(cs[1]) != 4 {
+		panic(fmt.Sprintf("expect two communities,but get %v", cs))
+	}
+	if q.Order() != 2 || q.Size() != 1 {
+		panic(fmt.Sprintf("quotient graph should has 2 vertexes and 1 edge,but get %d,%d", q.Order(), q.Size()))
+	}
+	m, err := Modularity(g, cs, 1.0)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(m, 2*(6.0/13-0.25)) {
+		panic(fmt.Sprintf("wrong modularity %v", m))
+	}
+	if _, err := Modularity(g, [][]int{{0, 1, 2, 3}}, 1.0); err == nil {
+		panic("partition should cover all vertexes")
+	}
+}
+
+func TestLabelPropagation(t *testing.T) {
+	g := testTwoCliques()
+	cs, _, err := LabelPropagation(g, false)
+	if err != nil {
+		panic(err.Error())
+	}
+	var n int
+	for _, c := range cs {
+		n += len(c)
+	}
+	if n != g.Order() {
+		panic(fmt.Sprintf("wrong partition %v", cs))
+	}
+	fmt.Println("label propagation:", cs)
+}
@@ -37,6 +37,8 @@ func Connected[K comparable, W number](g Graph[K, W], start, end K) (bool, error
 	return true, nil
 }
 
+// Every edge of the auxiliary graph has capacity 1, parallel edges are merged into one edge
+// whose capacity is the multiplicity, and loops are ignored (so the auxiliary graph is simple).
 func auxiliaryGraphEDP[K comparable, W number](g Graph[K, W]) (Graph[K, int], error) {
 	if g == nil {
 		return nil, errNilGraph
@@ -49,8 +51,27 @@ func auxiliaryGraphEDP[K comparable, W number](g Graph[K, W]) (Graph[K, int], er
 			return nil, err
 		}
 	}
+	type pair struct{ tail, head K }
+	edges := make(map[pair]*Edge[K, int])
+	var order []pair
 	for _, e := range es {
-		if err := aux.AddEdge(Edge[K, int]{Key: e.Key, Head: e.Head, Tail: e.Tail, Weight: 1}); err != nil {
+		if e.Head == e.Tail {
+			continue
+		}
+		p := pair{tail: e.Tail, head: e.Head}
+		if _, ok := edges[p]; !ok && !g.IsDigraph() {
+			p = pair{tail: e.Head, head: e.Tail}
+		}
+		if ae, ok := edges[p]; ok {
+			ae.Weight++
+			continue
+		}
+		p = pair{tail: e.Tail, head: e.Head}
+		edges[p] = &Edge[K, int]{Key: e.Key, Head: e.Head, Tail: e.Tail, Weight: 1}
+		order = append(order, p)
+	}
+	for _, p := range order {
+		if err := aux.AddEdge(*edges[p]); err != nil {
 			return nil, err
 		}
 	}
@@ -59,42 +80,72 @@ func auxiliaryGraphEDP[K comparable, W number](g Graph[K, W]) (Graph[K, int], er
 
 // The edge disjoint paths in the auxiliary digraph correspond to the node disjoint paths in the original graph.
 func auxiliaryGraphVDP[K comparable, W number](g Graph[K, W], source, target K) (Graph[int, int], int, int, error) {
+	aux, idx, err := auxiliaryGraphSplit(g)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	s, ok1 := idx[source]
+	t, ok2 := idx[target]
+	if !ok1 || !ok2 {
+		return nil, 0, 0, errVertexNotExists
+	}
+	// paths start from the out-vertex of source and end at the in-vertex of target.
+	return aux, s, -t, nil
+}
+
+// Split every vertex v (numbered i+1) into an in-vertex -(i+1) and an out-vertex i+1 joined by an arc with capacity 1,
+// and replace every arc (u,v) with an arc from the out-vertex of u to the in-vertex of v
+// (an undirected edge is replaced with two arcs). Parallel edges and loops are ignored.
+// The auxiliary graph is always a digraph, and the returned map records the number of every vertex.
+func auxiliaryGraphSplit[K comparable, W number](g Graph[K, W]) (Graph[int, int], map[K]int, error) {
 	if g == nil {
-		return nil, 0, 0, errNilGraph
+		return nil, nil, errNilGraph
 	}
-	aux := NewGraph[int, int](g.IsDigraph(), "")
+	aux := NewDigraph[int, int]("")
 	vs := g.AllVertexes()
 	es := g.AllEdges()
 	idx := make(map[K]int)
-	var ek, s, t int
+	var ek int
 	for i, v := range vs {
 		if err := aux.AddVertex(Vertex[int, int]{Key: -(i + 1)}); err != nil {
-			return nil, 0, 0, err
+			return nil, nil, err
 		}
 		if err := aux.AddVertex(Vertex[int, int]{Key: i + 1}); err != nil {
-			return nil, 0, 0, err
-		}
-		if v.Key == source {
-			s = -(i + 1)
-		}
-		if v.Key == target {
-			t = i + 1
+			return nil, nil, err
 		}
 		idx[v.Key] = i + 1
 		if err := aux.AddEdge(Edge[int, int]{Key: ek, Head: i + 1, Tail: -(i + 1), Weight: 1}); err != nil {
-			return nil, 0, 0, err
+			return nil, nil, err
 		}
 		ek++
 	}
 	// add edge
+	type pair struct{ tail, head int }
+	added := make(map[pair]bool)
+	addArc := func(tail, head int) error {
+		p := pair{tail: tail, head: head}
+		if tail == head || added[p] {
+			return nil
+		}
+		added[p] = true
+		if err := aux.AddEdge(Edge[int, int]{Key: ek, Head: -head, Tail: tail, Weight: 1}); err != nil {
+			return err
+		}
+		ek++
+		return nil
+	}
 	for _, e := range es {
 		i, j := idx[e.Head], idx[e.Tail]
-		if err := aux.AddEdge(Edge[int, int]{Key: ek, Head: -i, Tail: j, Weight: 1}); err != nil {
-			return nil, 0, 0, err
+		if err := addArc(j, i); err != nil {
+			return nil, nil, err
+		}
+		if !g.IsDigraph() {
+			if err := addArc(i, j); err != nil {
+				return nil, nil, err
+			}
 		}
-		ek++
 	}
-	return aux, s, t, nil
+	return aux, idx, nil
 }
 
 // Find maximum number of edge disjoint paths between two vertices.
@@ -125,6 +176,220 @@ func DigraphVertexDisjointPath[K comparable, W number](g Graph[K, W], source, ta
 	return VertexDisjointPath(g, source, target)
 }
 
+// Calculate the edge connectivity of graph g, i.e. the minimum number of edges whose removal disconnects g
+// (for digraph, makes g not strongly connected). The edge connectivity of graph with less than two vertexes is 0.
+// Since every edge cut separates a fixed vertex v from some other vertex, it's enough to compute
+// the maximum number of edge disjoint paths between v and every other vertex (in both directions for digraph).
+func EdgeConnectivity[K comparable, W number](g Graph[K, W]) (int, error) {
+	aux, err := auxiliaryGraphEDP(g)
+	if err != nil {
+		return 0, err
+	}
+	vs := aux.AllVertexes()
+	if len(vs) < 2 {
+		return 0, nil
+	}
+	s := vs[0].Key
+	res := aux.Size() + 1
+	for _, v := range vs[1:] {
+		f, err := MaxFlow(aux, s, v.Key)
+		if err != nil {
+			return 0, err
+		}
+		res = min(res, f)
+		if aux.IsDigraph() {
+			if f, err = MaxFlow(aux, v.Key, s); err != nil {
+				return 0, err
+			}
+			res = min(res, f)
+		}
+		if res == 0 {
+			break
+		}
+	}
+	return res, nil
+}
+
+// local vertex connectivity between two non-adjacent vertexes.
+func localVertexConnectivity[K comparable](aux Graph[int, int], idx map[K]int, source, target K) (int, error) {
+	return MaxFlow(aux, idx[source], -idx[target])
+}
+
+/*
+Calculate the vertex connectivity of graph g, i.e. the minimum number of vertexes whose removal disconnects g
+(for digraph, makes g not strongly connected) or leaves a single vertex. The vertex connectivity of complete graph K_n is n-1.
+Parallel edges and loops are ignored.
+
+For undirected graph the algorithm of Esfahanian and Hakimi is used: let v be a vertex with minimum degree,
+then every minimum vertex cut either does not contain v, which separates v from some non-adjacent vertex,
+or contains v, which separates two non-adjacent neighbours of v.
+For digraph, the minimum local connectivity between every ordered pair of non-adjacent vertexes is computed.
+*/
+func VertexConnectivity[K comparable, W number](g Graph[K, W]) (int, error) {
+	if g == nil {
+		return 0, errNilGraph
+	}
+	k, _, err := vertexConnectivity(g, -1)
+	return k, err
+}
+
+// calculate the vertex connectivity, if bound >= 0, stop as soon as the connectivity is found to be less than bound.
+// the second return value reports whether the connectivity is exact.
+func vertexConnectivity[K comparable, W number](g Graph[K, W], bound int) (int, bool, error) {
+	vs := g.AllVertexes()
+	n := len(vs)
+	if n < 2 {
+		return 0, true, nil
+	}
+	// neighbours of every vertex (for digraph, the out-neighbours).
+	adj := make(map[K]map[K]bool)
+	for _, v := range vs {
+		adj[v.Key] = make(map[K]bool)
+	}
+	for _, e := range g.AllEdges() {
+		if e.Head == e.Tail {
+			continue
+		}
+		adj[e.Tail][e.Head] = true
+		if !g.IsDigraph() {
+			adj[e.Head][e.Tail] = true
+		}
+	}
+	aux, idx, err := auxiliaryGraphSplit(g)
+	if err != nil {
+		return 0, false, err
+	}
+	res := n - 1
+	check := func(s, t K) (bool, error) {
+		if s == t || adj[s][t] {
+			return false, nil
+		}
+		c, err := localVertexConnectivity(aux, idx, s, t)
+		if err != nil {
+			return false, err
+		}
+		res = min(res, c)
+		return bound >= 0 && res < bound, nil
+	}
+	if g.IsDigraph() {
+		for _, s := range vs {
+			for _, t := range vs {
+				if stop, err := check(s.Key, t.Key); err != nil || stop {
+					return res, false, err
+				}
+			}
+		}
+		return res, true, nil
+	}
+	v := vs[0].Key
+	for _, u := range vs {
+		if len(adj[u.Key]) < len(adj[v]) {
+			v = u.Key
+		}
+	}
+	for _, u := range vs {
+		if stop, err := check(v, u.Key); err != nil || stop {
+			return res, false, err
+		}
+	}
+	var ns []K
+	for _, u := range vs {
+		if adj[v][u.Key] {
+			ns = append(ns, u.Key)
+		}
+	}
+	for i := range ns {
+		for j := i + 1; j < len(ns); j++ {
+			if stop, err := check(ns[i], ns[j]); err != nil || stop {
+				return res, false, err
+			}
+		}
+	}
+	return res, true, nil
+}
+
+// Check if graph g is k-connected, i.e. g has more than k vertexes and the vertex connectivity is at least k.
+func IsKConnected[K comparable, W number](g Graph[K, W], k int) (bool, error) {
+	if g == nil {
+		return false, errNilGraph
+	}
+	if k <= 0 {
+		return true, nil
+	}
+	if g.Order() <= k {
+		return false, nil
+	}
+	c, _, err := vertexConnectivity(g, k)
+	if err != nil {
+		return false, err
+	}
+	return c >= k, nil
+}
+
+/*
+Find a minimum vertex separator between two non-adjacent vertexes s and t,
+i.e. a minimum vertex set (not containing s and t) whose removal destroys all paths from s to t.
+
+By Menger's theorem the size of the separator equals the maximum number of vertex disjoint paths.
+After computing the maximum flow on the split auxiliary graph, every arc of the minimum cut
+is either the arc between the in-vertex and out-vertex of some vertex v, or an arc (u,v) of g,
+in the latter case u (or v if u is s) is chosen.
+*/
+func MinimumVertexSeparator[K comparable, W number](g Graph[K, W], s, t K) ([]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if s == t {
+		return nil, errAdjacentVertexes
+	}
+	for _, e := range g.AllEdges() {
+		if e.Tail == s && e.Head == t || !g.IsDigraph() && e.Tail == t && e.Head == s {
+			return nil, errAdjacentVertexes
+		}
+	}
+	aux, idx, err := auxiliaryGraphSplit(g)
+	if err != nil {
+		return nil, err
+	}
+	si, ok1 := idx[s]
+	ti, ok2 := idx[t]
+	if !ok1 || !ok2 {
+		return nil, errVertexNotExists
+	}
+	_, cut, err := dinic(aux, si, -ti)
+	if err != nil {
+		return nil, err
+	}
+	keys := make(map[int]K)
+	for k, i := range idx {
+		keys[i] = k
+	}
+	side := make(map[int]bool)
+	for _, v := range cut {
+		side[v] = true
+	}
+	var res []K
+	added := make(map[int]bool)
+	for _, e := range aux.AllEdges() {
+		if !side[e.Tail] || side[e.Head] {
+			continue
+		}
+		// arc from in-vertex -i to out-vertex i, or from out-vertex u to in-vertex -v.
+		v := e.Head
+		if e.Tail > 0 && e.Tail != si {
+			v = e.Tail
+		}
+		if v < 0 {
+			v = -v
+		}
+		if !added[v] {
+			added[v] = true
+			res = append(res, keys[v])
+		}
+	}
+	return res, nil
+}
+
 // Query the incut or outcut of vertex set X on directed graph g (the incut is composed of all directed arcs whose heads belong to X).
 func DigraphCut[K comparable, W number](g Digraph[K, W], X []K, incut bool) ([]Edge[K, W], error) {
 	var res []Edge[K, W]
@@ -363,7 +628,7 @@ func sccTarjan[K comparable, W number](g Digraph[K, W], condensation bool) ([][]
 	if g == nil {
 		return nil, nil, errNilGraph
 	}
-	vtx := g.AllEdges()
+	vtx := g.AllVertexes()
 	// 1.DFS search produces a DFS tree/forest
 	// 2.Strongly Connected Components form subtrees of the DFS tree.
 	// 3.If we can find the root of such subtrees, we can print/store all the nodes in that subtree (including the root) and that will be one SCC.
@@ -0,0 +1,157 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestDisjointPath(t *testing.T) {
+	n, err := VertexDisjointPath(PetersenGraph(), 0, 5)
+	if err != nil {
+		panic(err.Error())
+	}
+	if n != 3 {
+		panic(fmt.Sprintf("there should be 3 vertex disjoint paths,but get %d", n))
+	}
+	n, err = VertexDisjointPath(testBowtie(), 0, 3)
+	if err != nil {
+		panic(err.Error())
+	}
+	if n != 1 {
+		panic(fmt.Sprintf("there should be 1 vertex disjoint path,but get %d", n))
+	}
+	n, err = EdgeDisjointPath(testBowtie(), 0, 3)
+	if err != nil {
+		panic(err.Error())
+	}
+	if n != 2 {
+		panic(fmt.Sprintf("there should be 2 edge disjoint paths,but get %d", n))
+	}
+}
+
+func TestConnectivity(t *testing.T) {
+	cases := []struct {
+		g      Graph[int, int]
+		vertex int
+		edge   int
+	}{
+		{PetersenGraph(), 3, 3},
+		{CompleteGraph(5), 4, 4},
+		{Cycle(6), 2, 2},
+		{testBowtie(), 1, 1},
+		{testTwoTriangles(), 0, 0},
+	}
+	for i, c := range cases {
+		k, err := VertexConnectivity(c.g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if k != c.vertex {
+			panic(fmt.Sprintf("case %d: vertex connectivity should be %d,but get %d", i, c.vertex, k))
+		}
+		l, err := EdgeConnectivity(c.g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if l != c.edge {
+			panic(fmt.Sprintf("case %d: edge connectivity should be %d,but get %d", i, c.edge, l))
+		}
+	}
+	ok, err := IsKConnected(PetersenGraph(), 3)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !ok {
+		panic("petersen graph is 3-connected")
+	}
+	if ok, _ = IsKConnected(PetersenGraph(), 4); ok {
+		panic("petersen graph is not 4-connected")
+	}
+	// directed cycle.
+	g := NewDigraph[int, int]("")
+	for i := 0; i < 4; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i := 0; i < 4; i++ {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Tail: i, Head: (i + 1) % 4})
+	}
+	k, err := VertexConnectivity[int, int](g)
+	if err != nil {
+		panic(err.Error())
+	}
+	l, err := EdgeConnectivity[int, int](g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if k != 1 || l != 1 {
+		panic(fmt.Sprintf("wrong connectivity of directed cycle %d %d", k, l))
+	}
+}
+
+func TestMinimumVertexSeparator(t *testing.T) {
+	g := PetersenGraph()
+	sep, err := MinimumVertexSeparator(g, 0, 7)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(sep) != 3 {
+		panic(fmt.Sprintf("separator size should be 3,but get %v", sep))
+	}
+	for _, v := range sep {
+		if err = g.RemoveVertex(v); err != nil {
+			panic(err.Error())
+		}
+	}
+	if ok, _ := Connected(g, 0, 7); ok {
+		panic(fmt.Sprintf("%v is not a separator", sep))
+	}
+	if _, err = MinimumVertexSeparator(PetersenGraph(), 0, 1); err == nil {
+		panic("adjacent vertexes have no separator")
+	}
+}
+
+func TestStronglyConnectedComponentTarjan(t *testing.T) {
+	// the keys of edges are different from the keys of vertexes, and the vertex 5 is isolated.
+	g := NewDigraph[int, int]("")
+	for i := 0; i < 6; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, a := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {3, 4}, {4, 3}} {
+		if err := g.AddEdge(Edge[int, int]{Key: 100 + i, Tail: a[0], Head: a[1]}); err != nil {
+			panic(err.Error())
+		}
+	}
+	sccs, cond, err := StronglyConnectedComponentTarjan(g, true)
+	if err != nil {
+		panic(err.Error())
+	}
+	comp := make(map[int]int)
+	for i, c := range sccs {
+		for _, v := range c {
+			comp[v] = i
+		}
+	}
+	if len(sccs) != 3 || len(comp) != 6 || comp[0] != comp[1] || comp[1] != comp[2] || comp[3] != comp[4] || comp[0] == comp[3] {
+		panic(fmt.Sprintf("wrong strongly connected components %v", sccs))
+	}
+	if cond.Order() != 3 || cond.Size() != 1 {
+		panic(fmt.Sprintf("wrong condensation order=%d size=%d", cond.Order(), cond.Size()))
+	}
+	fmt.Println("=======> test strongly connected component pass")
+}
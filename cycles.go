@@ -0,0 +1,425 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Calculate the dimension of the cycle space of graph, i.e. m - n + c where c is the number of connected components.
+// For digraph the direction of arcs is ignored.
+func CycleSpaceDimension[K comparable, W number](g Graph[K, W]) (int, error) {
+	if g == nil {
+		return 0, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	return len(ig.edges) - ig.order() + len(ig.weakComponents()), nil
+}
+
+/*
+Calculate a fundamental cycle basis of undirected graph with respect to the minimum spanning forest:
+every edge outside the forest closes exactly one cycle with the tree path between its ends.
+A cycle is a list of edge keys in the order of traversal, loops are cycles of one edge and
+parallel edges form cycles of two edges.
+*/
+func FundamentalCycleBasis[K comparable, W number](g Graph[K, W]) ([][]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if g.IsDigraph() {
+		return nil, errDigraph
+	}
+	if g.Order() == 0 {
+		return nil, nil
+	}
+	trees, branches, _, err := MinWeightSpanningForest(g)
+	if err != nil {
+		return nil, err
+	}
+	// root every tree at its first vertex.
+	type treeNode struct {
+		parent K
+		edge   K
+		depth  int
+	}
+	nodes := make(map[K]treeNode)
+	inTree := make(map[K]bool)
+	for i, tree := range trees {
+		adj := make(map[K][]Edge[K, W])
+		for _, e := range branches[i] {
+			inTree[e.Key] = true
+			adj[e.Head] = append(adj[e.Head], e)
+			adj[e.Tail] = append(adj[e.Tail], e)
+		}
+		nodes[tree[0]] = treeNode{parent: tree[0]}
+		queue := []K{tree[0]}
+		for len(queue) != 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, e := range adj[v] {
+				w := e.Head
+				if w == v {
+					w = e.Tail
+				}
+				if _, ok := nodes[w]; !ok {
+					nodes[w] = treeNode{parent: v, edge: e.Key, depth: nodes[v].depth + 1}
+					queue = append(queue, w)
+				}
+			}
+		}
+	}
+
+	var basis [][]K
+	for _, e := range g.AllEdges() {
+		if inTree[e.Key] {
+			continue
+		}
+		// walk up from both ends to the common ancestor.
+		var up, down []K
+		u, v := e.Head, e.Tail
+		for u != v {
+			if nodes[u].depth >= nodes[v].depth {
+				up = append(up, nodes[u].edge)
+				u = nodes[u].parent
+			} else {
+				down = append(down, nodes[v].edge)
+				v = nodes[v].parent
+			}
+		}
+		for i := len(down) - 1; i >= 0; i-- {
+			up = append(up, down[i])
+		}
+		basis = append(basis, append(up, e.Key))
+	}
+	return basis, nil
+}
+
+/*
+Calculate a minimum weight cycle basis of undirected graph by Horton's algorithm, weights must be non-negative.
+For every vertex v a shortest path tree T(v) is built, and every edge (x,y) gives the candidate cycle
+T(v)[x] + (x,y) + T(v)[y] (as a set of edges over GF(2), common parts of the two tree paths cancel).
+Horton proved that the candidates contain a minimum cycle basis, so the candidates are sorted by weight
+and greedily added if they are linearly independent of the chosen ones (checked by Gaussian elimination over GF(2)).
+*/
+func MinimumCycleBasis[K comparable, W number](g Graph[K, W]) ([][]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if g.IsDigraph() {
+		return nil, errDigraph
+	}
+	ig := newIndexedGraph(g)
+	if ig.hasNegativeWeight() {
+		return nil, errNegativeWeight
+	}
+	m, n := len(ig.edges), ig.order()
+	dim := m - n + len(ig.weakComponents())
+	if dim == 0 {
+		return nil, nil
+	}
+
+	type candidate struct {
+		set    cycleVector
+		weight float64
+		size   int
+	}
+	var candidates []candidate
+	seen := make(map[string]bool)
+	add := func(set cycleVector) {
+		if s := set.String(); !seen[s] {
+			seen[s] = true
+			c := candidate{set: set}
+			for _, i := range set.edges() {
+				c.weight += float64(ig.edges[i].Weight)
+				c.size++
+			}
+			candidates = append(candidates, c)
+		}
+	}
+	for s := 0; s < n; s++ {
+		ss := ig.shortestPathDAG(s, true, true)
+		// the path from s to v in the shortest path tree.
+		paths := make([]cycleVector, n)
+		paths[s] = newCycleVector(m)
+		for _, v := range ss.order[1:] {
+			p := ss.pred[v][0]
+			paths[v] = paths[p.to].clone()
+			paths[v].flip(p.edge)
+		}
+		for i, e := range ig.edges {
+			x, y := ig.idx[e.Tail], ig.idx[e.Head]
+			if paths[x] == nil || paths[y] == nil {
+				continue
+			}
+			set := paths[x].clone()
+			set.xor(paths[y])
+			set.flip(i)
+			if !set.empty() {
+				add(set)
+			}
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].weight != candidates[j].weight {
+			return candidates[i].weight < candidates[j].weight
+		}
+		return candidates[i].size < candidates[j].size
+	})
+
+	var basis [][]K
+	pivots := make(map[int]cycleVector)
+	for _, c := range candidates {
+		r := c.set.clone()
+		for !r.empty() {
+			p := r.lowest()
+			row, ok := pivots[p]
+			if !ok {
+				pivots[p] = r
+				break
+			}
+			r.xor(row)
+		}
+		if r.empty() {
+			continue
+		}
+		basis = append(basis, ig.orderCycle(c.set.edges()))
+		if len(basis) == dim {
+			break
+		}
+	}
+	return basis, nil
+}
+
+/*
+Calculate a directed cycle basis of digraph: a maximal set of directed cycles whose arc sets are linearly independent.
+Arcs between strongly connected components lie on no directed cycle, so the basis has m' - n + s cycles
+where s is the number of strongly connected components and m' is the number of arcs inside them.
+
+Every strongly connected component is built by a directed ear decomposition: starting from a single vertex H,
+repeatedly take an arc (u,v) out of H which is not in H and the shortest path from v back to H (at w), this ear is added to H
+and closed to a directed cycle by the shortest path from w to u inside H. Every cycle contains the arcs of its own ear
+which are not in the previous cycles, so the cycles are independent.
+*/
+func DirectedCycleBasis[K comparable, W number](g Digraph[K, W]) ([][]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, errNotDigraph
+	}
+	sccs, _, err := StronglyConnectedComponent(g, false)
+	if err != nil {
+		return nil, err
+	}
+	ig := newIndexedGraph[K, W](g)
+	n := ig.order()
+	comp := make([]int, n)
+	for c, scc := range sccs {
+		for _, v := range scc {
+			comp[ig.idx[v]] = c
+		}
+	}
+	inH := make([]bool, n)
+	arcInH := make([]bool, len(ig.edges))
+	// the shortest path from s to a vertex satisfying target, only arcs satisfying use are followed.
+	bfs := func(s int, target func(int) bool, use func(arc) bool) []arc {
+		prev := make(map[int]arc)
+		visited := map[int]bool{s: true}
+		queue := []int{s}
+		for len(queue) != 0 {
+			v := queue[0]
+			queue = queue[1:]
+			if target(v) {
+				var path []arc
+				for v != s {
+					a := prev[v]
+					path = append(path, a)
+					v = a.to
+				}
+				for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+					path[i], path[j] = path[j], path[i]
+				}
+				return path
+			}
+			for _, a := range ig.out[v] {
+				if !visited[a.to] && use(a) {
+					visited[a.to] = true
+					// arc.to of the recorded arc is the previous vertex.
+					prev[a.to] = arc{to: v, edge: a.edge}
+					queue = append(queue, a.to)
+				}
+			}
+		}
+		return nil
+	}
+
+	var basis [][]K
+	for _, scc := range sccs {
+		root := ig.idx[scc[0]]
+		c := comp[root]
+		inH[root] = true
+		members := []int{root}
+		for i := 0; i < len(members); i++ {
+			u := members[i]
+			for _, a := range ig.out[u] {
+				if arcInH[a.edge] || comp[a.to] != c {
+					continue
+				}
+				// the ear u->v->...->w.
+				ear := append([]arc{{to: a.to, edge: a.edge}}, bfs(a.to, func(x int) bool { return inH[x] },
+					func(b arc) bool { return comp[b.to] == c })...)
+				var w int
+				for _, b := range ear {
+					w = ig.idx[ig.edges[b.edge].Head]
+				}
+				back := bfs(w, func(x int) bool { return x == u }, func(b arc) bool { return arcInH[b.edge] })
+				cycle := make([]K, 0, len(ear)+len(back))
+				for _, b := range ear {
+					arcInH[b.edge] = true
+					if h := ig.idx[ig.edges[b.edge].Head]; !inH[h] {
+						inH[h] = true
+						members = append(members, h)
+					}
+					cycle = append(cycle, ig.edges[b.edge].Key)
+				}
+				for _, b := range back {
+					cycle = append(cycle, ig.edges[b.edge].Key)
+				}
+				basis = append(basis, cycle)
+			}
+		}
+	}
+	return basis, nil
+}
+
+// weakComponents returns the connected components, the direction of arcs is ignored.
+func (g *indexedGraph[K, W]) weakComponents() [][]int {
+	n := g.order()
+	visited := make([]bool, n)
+	var comps [][]int
+	for s := 0; s < n; s++ {
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		comp := []int{s}
+		for i := 0; i < len(comp); i++ {
+			v := comp[i]
+			for _, as := range [][]arc{g.out[v], g.in[v]} {
+				for _, a := range as {
+					if !visited[a.to] {
+						visited[a.to] = true
+						comp = append(comp, a.to)
+					}
+				}
+			}
+		}
+		comps = append(comps, comp)
+	}
+	return comps
+}
+
+// orderCycle lists the edges of a cycle in the order of traversal.
+func (g *indexedGraph[K, W]) orderCycle(edges []int) []K {
+	incident := make(map[int][]int)
+	for _, i := range edges {
+		t, h := g.idx[g.edges[i].Tail], g.idx[g.edges[i].Head]
+		incident[t] = append(incident[t], i)
+		if t != h {
+			incident[h] = append(incident[h], i)
+		}
+	}
+	used := make(map[int]bool)
+	cycle := make([]K, 0, len(edges))
+	v := g.idx[g.edges[edges[0]].Tail]
+	for len(cycle) < len(edges) {
+		for _, i := range incident[v] {
+			if used[i] {
+				continue
+			}
+			used[i] = true
+			cycle = append(cycle, g.edges[i].Key)
+			if t := g.idx[g.edges[i].Tail]; t != v {
+				v = t
+			} else {
+				v = g.idx[g.edges[i].Head]
+			}
+			break
+		}
+	}
+	return cycle
+}
+
+// cycleVector is a set of edges, i.e. a vector over GF(2).
+type cycleVector []uint64
+
+func newCycleVector(m int) cycleVector {
+	return make(cycleVector, (m+63)/64)
+}
+
+func (c cycleVector) clone() cycleVector {
+	return append(cycleVector{}, c...)
+}
+
+func (c cycleVector) flip(i int) {
+	c[i/64] ^= 1 << (i % 64)
+}
+
+func (c cycleVector) xor(o cycleVector) {
+	for i := range c {
+		c[i] ^= o[i]
+	}
+}
+
+func (c cycleVector) empty() bool {
+	for _, x := range c {
+		if x != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (c cycleVector) lowest() int {
+	for i, x := range c {
+		if x != 0 {
+			return i*64 + bits.TrailingZeros64(x)
+		}
+	}
+	return -1
+}
+
+func (c cycleVector) edges() []int {
+	var res []int
+	for i, x := range c {
+		for ; x != 0; x &= x - 1 {
+			res = append(res, i*64+bits.TrailingZeros64(x))
+		}
+	}
+	return res
+}
+
+func (c cycleVector) String() string {
+	b := make([]byte, 0, 8*len(c))
+	for _, x := range c {
+		for k := 0; k < 64; k += 8 {
+			b = append(b, byte(x>>k))
+		}
+	}
+	return string(b)
+}
@@ -0,0 +1,211 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// checkCycleBasis checks that every cycle is a closed trail (a directed one for digraph)
+// and that the cycles are linearly independent over GF(2), it returns the total weight.
+func checkCycleBasis(g Graph[int, int], basis [][]int, dim int) int {
+	if len(basis) != dim {
+		panic(fmt.Sprintf("expect %d cycles, got %d: %v", dim, len(basis), basis))
+	}
+	m := g.Size()
+	var total int
+	pivots := make(map[int]cycleVector)
+	for _, c := range basis {
+		set := newCycleVector(m)
+		first, _ := g.GetEdgeByKey(c[0])
+		start := first.Tail
+		if last, _ := g.GetEdgeByKey(c[len(c)-1]); !g.IsDigraph() && last.Head != start && last.Tail != start {
+			start = first.Head
+		}
+		v := start
+		for _, k := range c {
+			e, err := g.GetEdgeByKey(k)
+			if err != nil {
+				panic(err)
+			}
+			switch {
+			case e.Tail == v:
+				v = e.Head
+			case e.Head == v && !g.IsDigraph():
+				v = e.Tail
+			default:
+				panic(fmt.Sprintf("%v is not a trail", c))
+			}
+			set.flip(k)
+			total += e.Weight
+		}
+		if v != start || len(set.edges()) != len(c) {
+			panic(fmt.Sprintf("%v is not a closed trail", c))
+		}
+		for !set.empty() {
+			row, ok := pivots[set.lowest()]
+			if !ok {
+				pivots[set.lowest()] = set
+				break
+			}
+			set.xor(row)
+		}
+		if set.empty() {
+			panic(fmt.Sprintf("cycles %v are not independent", basis))
+		}
+	}
+	return total
+}
+
+// bruteForceMinimumCycleBasis enumerates all cycles and chooses greedily, returns the total weight.
+func bruteForceMinimumCycleBasis(n int, es [][3]int) int {
+	m := len(es)
+	var cycles []cycleVector
+	seen := make(map[string]bool)
+	used := make([]bool, n)
+	var dfs func(s, v, first int, set cycleVector)
+	dfs = func(s, v, first int, set cycleVector) {
+		for i, e := range es {
+			w := -1
+			if e[0] == v {
+				w = e[1]
+			} else if e[1] == v {
+				w = e[0]
+			}
+			if w < 0 || i == first || set[i/64]&(1<<(i%64)) != 0 {
+				continue
+			}
+			next := set.clone()
+			next.flip(i)
+			if w == s {
+				if !seen[next.String()] {
+					seen[next.String()] = true
+					cycles = append(cycles, next)
+				}
+			} else if w > s && !used[w] {
+				used[w] = true
+				f := first
+				if f < 0 {
+					f = i
+				}
+				dfs(s, w, f, next)
+				used[w] = false
+			}
+		}
+	}
+	for s := 0; s < n; s++ {
+		used[s] = true
+		dfs(s, s, -1, newCycleVector(m))
+		used[s] = false
+	}
+	weight := func(c cycleVector) int {
+		var w int
+		for _, i := range c.edges() {
+			w += es[i][2]
+		}
+		return w
+	}
+	sort.SliceStable(cycles, func(i, j int) bool { return weight(cycles[i]) < weight(cycles[j]) })
+	var total int
+	pivots := make(map[int]cycleVector)
+	for _, c := range cycles {
+		r := c.clone()
+		for !r.empty() {
+			row, ok := pivots[r.lowest()]
+			if !ok {
+				pivots[r.lowest()] = r
+				total += weight(c)
+				break
+			}
+			r.xor(row)
+		}
+	}
+	return total
+}
+
+func TestCycleBasis(t *testing.T) {
+	r := rand.New(rand.NewSource(48))
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(7)
+		var es [][3]int
+		for k := r.Intn(2*n + 2); k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), r.Intn(10)})
+		}
+		g := testMultigraph(false, n, es)
+		dim, err := CycleSpaceDimension(g)
+		if err != nil {
+			panic(err)
+		}
+		fb, err := FundamentalCycleBasis(g)
+		if err != nil {
+			panic(err)
+		}
+		checkCycleBasis(g, fb, dim)
+		mb, err := MinimumCycleBasis(g)
+		if err != nil {
+			panic(err)
+		}
+		if w, want := checkCycleBasis(g, mb, dim), bruteForceMinimumCycleBasis(n, es); w != want {
+			panic(fmt.Sprintf("minimum cycle basis of %v: expect weight %d, got %d %v", es, want, w, mb))
+		}
+
+		// directed
+		dg := testMultigraph(true, n, es).(Digraph[int, int])
+		sccs, _, err := StronglyConnectedComponent(dg, false)
+		if err != nil {
+			panic(err)
+		}
+		comp := make(map[int]int)
+		for i, c := range sccs {
+			for _, v := range c {
+				comp[v] = i
+			}
+		}
+		dim = -n + len(sccs)
+		for _, e := range es {
+			if comp[e[0]] == comp[e[1]] {
+				dim++
+			}
+		}
+		db, err := DirectedCycleBasis(dg)
+		if err != nil {
+			panic(err)
+		}
+		checkCycleBasis(dg, db, dim)
+	}
+
+	// all the 5-cycles of Petersen graph.
+	mb, err := MinimumCycleBasis(PetersenGraph())
+	if err != nil {
+		panic(err)
+	}
+	for _, c := range mb {
+		if len(c) != 5 {
+			panic(fmt.Sprintf("unexpected cycle %v", c))
+		}
+	}
+	if len(mb) != 6 {
+		panic(fmt.Sprintf("expect 6 cycles, got %d", len(mb)))
+	}
+	if _, err = MinimumCycleBasis(testMultigraph(true, 1, nil)); err != errDigraph {
+		panic("expect errDigraph")
+	}
+	fmt.Println("=======> test cycle basis pass")
+}
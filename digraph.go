@@ -53,6 +53,7 @@ type Digraph[K comparable, W number] interface {
 	// All vertices with degree 0.
 	Sinks() ([]Vertex[K, W], error)
 	//
+	// Detect cycles, every cycle is a list of edge keys starting with an arc of a minimal feedback arc set.
 	DetectCycle() ([][]K, error)
 	//
 	// Reverse all edges in a directed graph.
@@ -148,8 +149,53 @@ func (g *graph[K, W]) Sinks() ([]Vertex[K, W], error) {
 	return g.getVertexes(vs)
 }
 
+/*
+Detect cycles of the digraph, a nil slice is returned if it is acyclic.
+Every returned cycle is a list of edge keys which starts with an arc of a minimal feedback arc set (see MinimumFeedbackArcSet),
+so removing the first arc of every cycle makes the digraph acyclic, and each of these arcs is necessary.
+*/
 func (g *graph[K, W]) DetectCycle() ([][]K, error) {
-	return nil, errNotImplement
+	if !g.IsDigraph() {
+		return nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	fas := ig.feedbackArcSet()
+	removed := make([]bool, len(ig.edges))
+	for _, e := range fas {
+		removed[e] = true
+	}
+	var cycles [][]K
+	for _, e := range fas {
+		// the shortest path from the head back to the tail without the removed arcs.
+		tail, head := ig.idx[ig.edges[e].Tail], ig.idx[ig.edges[e].Head]
+		cycle := []K{ig.edges[e].Key}
+		if tail != head {
+			prev := map[int]int{head: -1}
+			queue := []int{head}
+			for len(queue) != 0 {
+				if _, ok := prev[tail]; ok {
+					break
+				}
+				v := queue[0]
+				queue = queue[1:]
+				for _, a := range ig.out[v] {
+					if _, ok := prev[a.to]; !ok && !removed[a.edge] {
+						prev[a.to] = a.edge
+						queue = append(queue, a.to)
+					}
+				}
+			}
+			var path []K
+			for v := tail; v != head; v = ig.idx[ig.edges[prev[v]].Tail] {
+				path = append(path, ig.edges[prev[v]].Key)
+			}
+			for i := len(path) - 1; i >= 0; i-- {
+				cycle = append(cycle, path[i])
+			}
+		}
+		cycles = append(cycles, cycle)
+	}
+	return cycles, nil
 }
 
 func (g *graph[K, W]) Reverse() error {
@@ -163,6 +209,7 @@ func (g *graph[K, W]) Reverse() error {
 	for _, e := range g.edges {
 		e.Head, e.Tail = e.Tail, e.Head
 	}
+	g.ver++
 	return nil
 }
 
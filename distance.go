@@ -0,0 +1,269 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "math"
+
+// Calculate the eccentricity of every vertex, i.e. the greatest distance from the vertex to the other vertexes.
+// If weighted is true the length of an edge is its weight (must be non-negative), otherwise every edge has length 1.
+// For digraph the distances follow the direction of arcs, the eccentricity is +Inf if some vertex is unreachable.
+func Eccentricity[K comparable, W number](g Graph[K, W], weighted bool) (map[K]float64, error) {
+	ig, ecc, err := eccentricity(g, weighted)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[K]float64, len(ecc))
+	for i, e := range ecc {
+		res[ig.keys[i]] = e
+	}
+	return res, nil
+}
+
+// Calculate the diameter of graph, i.e. the maximum eccentricity, it is +Inf if the graph is not (strongly) connected.
+func Diameter[K comparable, W number](g Graph[K, W], weighted bool) (float64, error) {
+	_, ecc, err := eccentricity(g, weighted)
+	if err != nil {
+		return 0, err
+	}
+	if len(ecc) == 0 {
+		return 0, errEmptyGraph
+	}
+	d, _ := eccentricityRange(ecc)
+	return d, nil
+}
+
+// Calculate the radius of graph, i.e. the minimum eccentricity.
+func Radius[K comparable, W number](g Graph[K, W], weighted bool) (float64, error) {
+	_, ecc, err := eccentricity(g, weighted)
+	if err != nil {
+		return 0, err
+	}
+	if len(ecc) == 0 {
+		return 0, errEmptyGraph
+	}
+	_, r := eccentricityRange(ecc)
+	return r, nil
+}
+
+// Calculate the center of graph, i.e. the vertexes whose eccentricity equals the radius.
+func Center[K comparable, W number](g Graph[K, W], weighted bool) ([]K, error) {
+	ig, ecc, err := eccentricity(g, weighted)
+	if err != nil {
+		return nil, err
+	}
+	_, r := eccentricityRange(ecc)
+	var res []K
+	for i, e := range ecc {
+		if e == r {
+			res = append(res, ig.keys[i])
+		}
+	}
+	return res, nil
+}
+
+// Calculate the periphery of graph, i.e. the vertexes whose eccentricity equals the diameter.
+func Periphery[K comparable, W number](g Graph[K, W], weighted bool) ([]K, error) {
+	ig, ecc, err := eccentricity(g, weighted)
+	if err != nil {
+		return nil, err
+	}
+	d, _ := eccentricityRange(ecc)
+	var res []K
+	for i, e := range ecc {
+		if e == d {
+			res = append(res, ig.keys[i])
+		}
+	}
+	return res, nil
+}
+
+// Calculate the Wiener index of graph, i.e. the sum of the distances between all unordered pairs of vertexes
+// (ordered pairs for digraph), it is +Inf if the graph is not (strongly) connected.
+func WienerIndex[K comparable, W number](g Graph[K, W], weighted bool) (float64, error) {
+	ig, err := distanceGraph(g, weighted)
+	if err != nil {
+		return 0, err
+	}
+	var sum float64
+	for s := 0; s < ig.order(); s++ {
+		for _, d := range ig.shortestPathDAG(s, weighted, false).dist {
+			if d < 0 {
+				return math.Inf(1), nil
+			}
+			sum += d
+		}
+	}
+	if !ig.digraph {
+		sum /= 2
+	}
+	return sum, nil
+}
+
+/*
+Calculate the girth of graph, i.e. the length of the shortest cycle, it is +Inf if the graph is acyclic.
+Loops are cycles of length 1 and parallel edges form cycles of length 2 (as do opposite arcs of digraph).
+In the weighted case the length of a cycle is the total weight of its edges.
+
+The unweighted girth is found by a BFS from every vertex: for undirected graph every non-tree edge (v,w)
+closes a walk of length d(v)+d(w)+1 which contains a cycle, and the shortest cycle through the root
+is found exactly this way; for digraph the shortest cycle through the root s is min d(v)+1 over arcs (v,s).
+The weighted girth replaces BFS by Dijkstra, for undirected graph the shortest cycle through edge (u,v)
+is w(u,v) plus the shortest u-v path avoiding that edge.
+*/
+func Girth[K comparable, W number](g Graph[K, W], weighted bool) (float64, error) {
+	ig, err := distanceGraph(g, weighted)
+	if err != nil {
+		return 0, err
+	}
+	length := func(a arc) float64 {
+		if weighted {
+			return a.weight
+		}
+		return 1
+	}
+	girth := math.Inf(1)
+	for v := range ig.out {
+		for _, a := range ig.out[v] {
+			if a.to == v {
+				girth = math.Min(girth, length(a))
+			}
+		}
+	}
+	n := ig.order()
+	switch {
+	case ig.digraph:
+		for s := 0; s < n; s++ {
+			dist := ig.shortestPathDAG(s, weighted, false).dist
+			for _, a := range ig.in[s] {
+				if a.to != s && dist[a.to] >= 0 {
+					girth = math.Min(girth, dist[a.to]+length(a))
+				}
+			}
+		}
+	case !weighted:
+		dist := make([]float64, n)
+		parent := make([]int, n)
+		for s := 0; s < n; s++ {
+			for i := range dist {
+				dist[i] = -1
+			}
+			dist[s], parent[s] = 0, -1
+			queue := newFIFO[int]()
+			queue.push(s)
+			for !queue.empty() {
+				v, _ := queue.pop()
+				// no shorter cycle can be found from deeper vertexes.
+				if 2*dist[v] >= girth {
+					break
+				}
+				for _, a := range ig.out[v] {
+					if a.edge == parent[v] || a.to == v {
+						continue
+					}
+					if dist[a.to] < 0 {
+						dist[a.to], parent[a.to] = dist[v]+1, a.edge
+						queue.push(a.to)
+					} else {
+						girth = math.Min(girth, dist[v]+dist[a.to]+1)
+					}
+				}
+			}
+		}
+	default:
+		for i, e := range ig.edges {
+			u, v := ig.idx[e.Tail], ig.idx[e.Head]
+			if u == v {
+				continue
+			}
+			if d := ig.avoidingDistance(u, v, i, girth-float64(e.Weight)); d >= 0 {
+				girth = math.Min(girth, d+float64(e.Weight))
+			}
+		}
+	}
+	return girth, nil
+}
+
+// avoidingDistance calculates the length of the shortest s-t path without edge skip by Dijkstra,
+// the search stops once the distance reaches bound. It returns -1 if no such path shorter than bound.
+func (g *indexedGraph[K, W]) avoidingDistance(s, t, skip int, bound float64) float64 {
+	dist := make(map[int]float64)
+	done := make(map[int]bool)
+	pq := NewPriorityQueue[int, float64](func(p1, p2 float64) bool { return p1 < p2 })
+	dist[s] = 0
+	pq.Push(s, 0)
+	for pq.Len() != 0 {
+		v, d, _ := pq.Pop()
+		if done[v] || d > dist[v] {
+			continue
+		}
+		if d >= bound {
+			return -1
+		}
+		if v == t {
+			return d
+		}
+		done[v] = true
+		for _, a := range g.out[v] {
+			if a.edge == skip {
+				continue
+			}
+			if old, ok := dist[a.to]; !ok || d+a.weight < old {
+				dist[a.to] = d + a.weight
+				pq.Push(a.to, d+a.weight)
+			}
+		}
+	}
+	return -1
+}
+
+func distanceGraph[K comparable, W number](g Graph[K, W], weighted bool) (*indexedGraph[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	if weighted && ig.hasNegativeWeight() {
+		return nil, errNegativeWeight
+	}
+	return ig, nil
+}
+
+func eccentricity[K comparable, W number](g Graph[K, W], weighted bool) (*indexedGraph[K, W], []float64, error) {
+	ig, err := distanceGraph(g, weighted)
+	if err != nil {
+		return nil, nil, err
+	}
+	ecc := make([]float64, ig.order())
+	for s := range ecc {
+		for _, d := range ig.shortestPathDAG(s, weighted, false).dist {
+			if d < 0 {
+				ecc[s] = math.Inf(1)
+				break
+			}
+			ecc[s] = math.Max(ecc[s], d)
+		}
+	}
+	return ig, ecc, nil
+}
+
+// eccentricityRange returns the diameter and radius.
+func eccentricityRange(ecc []float64) (float64, float64) {
+	d, r := math.Inf(-1), math.Inf(1)
+	for _, e := range ecc {
+		d, r = math.Max(d, e), math.Min(r, e)
+	}
+	return d, r
+}
@@ -0,0 +1,208 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// bruteForceGirth enumerates all cycles by DFS.
+func bruteForceGirth(digraph bool, n int, es [][3]int, weighted bool) float64 {
+	length := func(e [3]int) float64 {
+		if weighted {
+			return float64(e[2])
+		}
+		return 1
+	}
+	girth := math.Inf(1)
+	used := make([]bool, n)
+	var dfs func(s, v, first int, l float64)
+	dfs = func(s, v, first int, l float64) {
+		for i, e := range es {
+			w := -1
+			if e[0] == v {
+				w = e[1]
+			} else if !digraph && e[1] == v {
+				w = e[0]
+			}
+			if w < 0 || i == first || e[0] == e[1] {
+				continue
+			}
+			if w == s {
+				girth = math.Min(girth, l+length(e))
+			} else if w > s && !used[w] {
+				used[w] = true
+				f := first
+				if f < 0 {
+					f = i
+				}
+				dfs(s, w, f, l+length(e))
+				used[w] = false
+			}
+		}
+	}
+	for _, e := range es {
+		if e[0] == e[1] {
+			girth = math.Min(girth, length(e))
+		}
+	}
+	for s := 0; s < n; s++ {
+		used[s] = true
+		dfs(s, s, -1, 0)
+		used[s] = false
+	}
+	return girth
+}
+
+func TestDistanceMeasures(t *testing.T) {
+	r := rand.New(rand.NewSource(47))
+	for round := 0; round < 300; round++ {
+		n := 1 + r.Intn(8)
+		var es [][3]int
+		for k := r.Intn(2 * n); k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), r.Intn(10)})
+		}
+		digraph := round%2 == 1
+		g := testMultigraph(digraph, n, es)
+		for _, weighted := range []bool{false, true} {
+			// Floyd-Warshall
+			dist := make([][]float64, n)
+			for i := range dist {
+				dist[i] = make([]float64, n)
+				for j := range dist[i] {
+					if i != j {
+						dist[i][j] = math.Inf(1)
+					}
+				}
+			}
+			for _, e := range es {
+				w := 1.0
+				if weighted {
+					w = float64(e[2])
+				}
+				dist[e[0]][e[1]] = math.Min(dist[e[0]][e[1]], w)
+				if !digraph {
+					dist[e[1]][e[0]] = math.Min(dist[e[1]][e[0]], w)
+				}
+			}
+			for k := 0; k < n; k++ {
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						dist[i][j] = math.Min(dist[i][j], dist[i][k]+dist[k][j])
+					}
+				}
+			}
+			ecc, err := Eccentricity(g, weighted)
+			if err != nil {
+				panic(err)
+			}
+			var wiener float64
+			for i := 0; i < n; i++ {
+				var e float64
+				for j := 0; j < n; j++ {
+					e = math.Max(e, dist[i][j])
+					wiener += dist[i][j]
+				}
+				if ecc[i] != e {
+					panic(fmt.Sprintf("eccentricity of %d: expect %f, got %f", i, e, ecc[i]))
+				}
+			}
+			if !digraph {
+				wiener /= 2
+			}
+			if w, _ := WienerIndex(g, weighted); w != wiener {
+				panic(fmt.Sprintf("wiener index: expect %f, got %f", wiener, w))
+			}
+			want := bruteForceGirth(digraph, n, es, weighted)
+			if girth, _ := Girth(g, weighted); girth != want {
+				panic(fmt.Sprintf("girth of %v (digraph %v, weighted %v): expect %f, got %f", es, digraph, weighted, want, girth))
+			}
+			d, _ := Diameter(g, weighted)
+			c, _ := Center(g, weighted)
+			p, _ := Periphery(g, weighted)
+			for _, v := range c {
+				if rd, _ := Radius(g, weighted); ecc[v] != rd {
+					panic("center vertex is not of minimum eccentricity")
+				}
+			}
+			for _, v := range p {
+				if ecc[v] != d {
+					panic("periphery vertex is not of maximum eccentricity")
+				}
+			}
+		}
+	}
+	fmt.Println("=======> test distance measures pass")
+}
+
+func TestDistanceProperties(t *testing.T) {
+	check := func(g Graph[int, int], p PropertyName, want float64) {
+		v, err := g.Property(p)
+		if err != nil {
+			panic(err)
+		}
+		if v.Value.(float64) != want {
+			panic(fmt.Sprintf("property %d: expect %f, got %v", p, want, v.Value))
+		}
+	}
+	pg := PetersenGraph()
+	check(pg, ProDiameter, 2)
+	check(pg, ProRadius, 2)
+	check(pg, ProGirth, 5)
+	check(pg, ProWienerIndex, 75)
+	q := Hypercube(3)
+	check(q, ProGirth, 4)
+	check(q, ProWienerIndex, 48)
+
+	// the cached values are refreshed after the graph changed.
+	g := testMultigraph(false, 4, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}})
+	check(g, ProDiameter, 3)
+	check(g, ProGirth, math.Inf(1))
+	check(g, ProWienerIndex, 10)
+	if err := g.AddEdge(Edge[int, int]{Key: 3, Tail: 3, Head: 0}); err != nil {
+		panic(err)
+	}
+	check(g, ProDiameter, 2)
+	check(g, ProRadius, 2)
+	check(g, ProGirth, 4)
+	check(g, ProWienerIndex, 8)
+	if _, err := NewGraph[int, int](false, "").Property(ProDiameter); err != errEmptyGraph {
+		panic("expect errEmptyGraph")
+	}
+
+	// weighted
+	w := testMultigraph(true, 3, [][3]int{{0, 1, 2}, {1, 2, 3}, {2, 0, 4}, {1, 0, 7}})
+	if d, _ := Girth(w, true); d != 9 {
+		panic(fmt.Sprintf("expect girth 9, got %f", d))
+	}
+	if err := w.SetEdgeWeight(3, 1); err != nil {
+		panic(err)
+	}
+	if d, _ := Girth(w, true); d != 3 {
+		panic(fmt.Sprintf("expect girth 3, got %f", d))
+	}
+	if c, _ := Center(w, true); len(c) != 1 || c[0] != 1 {
+		panic(fmt.Sprintf("unexpected center %v", c))
+	}
+	if _, err := Diameter(testMultigraph(false, 2, [][3]int{{0, 1, -1}}), true); err != errNegativeWeight {
+		panic("expect errNegativeWeight")
+	}
+	fmt.Println("=======> test distance properties pass")
+}
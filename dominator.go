@@ -0,0 +1,267 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+/*
+Lengauer-Tarjan algorithm, compute the immediate dominator of every vertex reachable from entry in O(m log n) time.
+If reverse is true the arcs are reversed, i.e. the immediate post-dominators are computed.
+
+ 1. Number the vertexes in dfs order, and record the parent of every vertex in the dfs tree.
+ 2. Compute the semi-dominator of every vertex in reverse dfs order:
+    sdom(w) = min{ v | there is a path v=v0,v1,...,vk=w such that vi > w for 0<i<k },
+    which is the minimum of dfn(v) for predecessors v < w, and sdom(u) for predecessors v > w where u is an ancestor of v with u > w.
+    The ancestors are maintained in a forest with path compression (eval and link).
+ 3. Compute the immediate dominators by the semi-dominators:
+    let u be the vertex with minimum sdom on the tree path from sdom(w) (excluded) to w,
+    then idom(w) = sdom(w) if sdom(u) = sdom(w), otherwise idom(w) = idom(u).
+
+The idom of unreachable vertexes is -1, and the idom of entry is itself.
+*/
+func (g *indexedGraph[K, W]) dominators(entry int, reverse bool) []int {
+	n := g.order()
+	succ, pred := g.out, g.in
+	if reverse {
+		succ, pred = g.in, g.out
+	}
+	dfn := make([]int, n)
+	for i := range dfn {
+		dfn[i] = -1
+	}
+	var vertex []int
+	parent := make([]int, n)
+	var dfs func(v int)
+	dfs = func(v int) {
+		dfn[v] = len(vertex)
+		vertex = append(vertex, v)
+		for _, a := range succ[v] {
+			if dfn[a.to] == -1 {
+				parent[a.to] = v
+				dfs(a.to)
+			}
+		}
+	}
+	dfs(entry)
+
+	semi := make([]int, n)
+	label := make([]int, n)
+	ancestor := make([]int, n)
+	idom := make([]int, n)
+	bucket := make([][]int, n)
+	for v := 0; v < n; v++ {
+		semi[v], label[v], ancestor[v], idom[v] = dfn[v], v, -1, -1
+	}
+	var compress func(v int)
+	compress = func(v int) {
+		a := ancestor[v]
+		if ancestor[a] == -1 {
+			return
+		}
+		compress(a)
+		if semi[label[a]] < semi[label[v]] {
+			label[v] = label[a]
+		}
+		ancestor[v] = ancestor[a]
+	}
+	eval := func(v int) int {
+		if ancestor[v] == -1 {
+			return v
+		}
+		compress(v)
+		return label[v]
+	}
+	for i := len(vertex) - 1; i > 0; i-- {
+		w := vertex[i]
+		for _, a := range pred[w] {
+			if dfn[a.to] == -1 {
+				continue
+			}
+			if u := eval(a.to); semi[u] < semi[w] {
+				semi[w] = semi[u]
+			}
+		}
+		s := vertex[semi[w]]
+		bucket[s] = append(bucket[s], w)
+		p := parent[w]
+		ancestor[w] = p
+		for _, v := range bucket[p] {
+			if u := eval(v); semi[u] < semi[v] {
+				idom[v] = u
+			} else {
+				idom[v] = p
+			}
+		}
+		bucket[p] = nil
+	}
+	for i := 1; i < len(vertex); i++ {
+		w := vertex[i]
+		if idom[w] != vertex[semi[w]] {
+			idom[w] = idom[idom[w]]
+		}
+	}
+	idom[entry] = entry
+	return idom
+}
+
+// Build the dominator tree, the parent of every vertex is its immediate dominator,
+// the key of the edge from idom(v) to v is the key of v.
+func dominatorTree[K comparable, W number](g Graph[K, W], ig *indexedGraph[K, W], entry int, idom []int) (*Forest[K, W], error) {
+	f := NewForest[K, W]()
+	for v, d := range idom {
+		if d == -1 {
+			continue
+		}
+		vtx, err := g.GetVertex(ig.keys[v])
+		if err != nil {
+			return nil, err
+		}
+		if err := f.AddVertex(vtx); err != nil {
+			return nil, err
+		}
+	}
+	for v, d := range idom {
+		if d == -1 || v == entry {
+			continue
+		}
+		if err := f.AddEdge(Edge[K, W]{Key: ig.keys[v], Head: ig.keys[v], Tail: ig.keys[d]}); err != nil {
+			return nil, err
+		}
+	}
+	f.SetRoot(ig.keys[entry])
+	f.SetDirected(ig.keys[entry])
+	return f, nil
+}
+
+func immediateDominators[K comparable, W number](g Digraph[K, W], entry K, reverse bool) (*indexedGraph[K, W], []int, error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	r, ok := ig.idx[entry]
+	if !ok {
+		return nil, nil, errVertexNotExists
+	}
+	return ig, ig.dominators(r, reverse), nil
+}
+
+// Calculate the immediate dominator of every vertex reachable from entry.
+// Vertex u dominates v if every path from entry to v goes through u, and the immediate dominator of v
+// is the unique dominator of v (other than v) which is dominated by all other dominators of v.
+// The entry vertex has no immediate dominator, so it is not in the result.
+func ImmediateDominators[K comparable, W number](g Digraph[K, W], entry K) (map[K]K, error) {
+	ig, idom, err := immediateDominators(g, entry, false)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[K]K)
+	for v, d := range idom {
+		if d != -1 && d != v {
+			res[ig.keys[v]] = ig.keys[d]
+		}
+	}
+	return res, nil
+}
+
+// Calculate the dominator tree of digraph g by Lengauer-Tarjan algorithm.
+// The tree is rooted at entry and contains all vertexes reachable from entry,
+// the parent of every vertex is its immediate dominator, so u dominates v if and only if u is an ancestor of v.
+// Every tree edge is directed from the immediate dominator to the vertex, and its key is the key of the vertex.
+func Dominators[K comparable, W number](g Digraph[K, W], entry K) (*Forest[K, W], error) {
+	ig, idom, err := immediateDominators(g, entry, false)
+	if err != nil {
+		return nil, err
+	}
+	return dominatorTree[K, W](g, ig, ig.idx[entry], idom)
+}
+
+// Calculate the post-dominator tree of digraph g, i.e. the dominator tree of the reverse graph rooted at exit.
+// Vertex u post-dominates v if every path from v to exit goes through u.
+// The tree contains all vertexes which can reach exit.
+func PostDominators[K comparable, W number](g Digraph[K, W], exit K) (*Forest[K, W], error) {
+	ig, idom, err := immediateDominators(g, exit, true)
+	if err != nil {
+		return nil, err
+	}
+	return dominatorTree[K, W](g, ig, ig.idx[exit], idom)
+}
+
+/*
+Calculate the dominance frontier of every vertex reachable from entry.
+The dominance frontier of u is the set of vertexes v such that u dominates a predecessor of v but does not strictly dominate v,
+i.e. where the dominance of u ends.
+
+For every vertex v with at least two predecessors, walk up the dominator tree from every predecessor p
+until reaching idom(v), v is in the frontier of every vertex on the walk (Cooper, Harvey and Kennedy).
+*/
+func DominanceFrontiers[K comparable, W number](g Digraph[K, W], entry K) (map[K][]K, error) {
+	ig, idom, err := immediateDominators(g, entry, false)
+	if err != nil {
+		return nil, err
+	}
+	return ig.dominanceFrontiers(idom, ig.in), nil
+}
+
+// Calculate the post-dominance frontier of every vertex which can reach exit,
+// i.e. the dominance frontiers of the reverse graph.
+func PostDominanceFrontiers[K comparable, W number](g Digraph[K, W], exit K) (map[K][]K, error) {
+	ig, idom, err := immediateDominators(g, exit, true)
+	if err != nil {
+		return nil, err
+	}
+	return ig.dominanceFrontiers(idom, ig.out), nil
+}
+
+func (g *indexedGraph[K, W]) dominanceFrontiers(idom []int, pred [][]arc) map[K][]K {
+	df := make([]map[int]bool, g.order())
+	for v, d := range idom {
+		if d == -1 {
+			continue
+		}
+		df[v] = make(map[int]bool)
+	}
+	for v, d := range idom {
+		// the entry has an implicit predecessor, and it does not strictly dominate itself.
+		entry := d == v
+		if d == -1 || len(pred[v]) < 2 && !entry {
+			continue
+		}
+		for _, a := range pred[v] {
+			for r := a.to; idom[r] != -1; r = idom[r] {
+				if r == d && !entry {
+					break
+				}
+				df[r][v] = true
+				if idom[r] == r {
+					break
+				}
+			}
+		}
+	}
+	res := make(map[K][]K)
+	for u, vs := range df {
+		if vs == nil {
+			continue
+		}
+		res[g.keys[u]] = []K{}
+		for v := range vs {
+			res[g.keys[u]] = append(res[g.keys[u]], g.keys[v])
+		}
+	}
+	return res
+}
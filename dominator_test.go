@@ -0,0 +1,82 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+)
+
+// a loop 1 -> {2,3} -> 4 -> 1 between entry 0 and exit 5, and vertex 6 is unreachable from 0.
+func testFlowGraph() Digraph[int, int] {
+	g := NewDigraph[int, int]("flow")
+	for i := 0; i < 7; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, e := range [][2]int{{0, 1}, {1, 2}, {1, 3}, {2, 4}, {3, 4}, {4, 1}, {4, 5}, {6, 5}} {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Tail: e[0], Head: e[1]})
+	}
+	return g
+}
+
+func TestDominators(t *testing.T) {
+	g := testFlowGraph()
+	idom, err := ImmediateDominators(g, 0)
+	if err != nil {
+		panic(err.Error())
+	}
+	expect := map[int]int{1: 0, 2: 1, 3: 1, 4: 1, 5: 4}
+	if len(idom) != len(expect) {
+		panic(fmt.Sprintf("wrong immediate dominators %v", idom))
+	}
+	for v, d := range expect {
+		if idom[v] != d {
+			panic(fmt.Sprintf("idom of %d should be %d,but get %d", v, d, idom[v]))
+		}
+	}
+	tree, err := Dominators(g, 0)
+	if err != nil {
+		panic(err.Error())
+	}
+	if r, _ := tree.Root(5); r != 0 || tree.Order() != 6 || !tree.IsDirected(5) {
+		panic("wrong dominator tree")
+	}
+	if a, _ := tree.LeastCommonAncestor(2, 5); a != 1 {
+		panic(fmt.Sprintf("lca of 2 and 5 should be 1,but get %d", a))
+	}
+	pt, err := PostDominators(g, 5)
+	if err != nil {
+		panic(err.Error())
+	}
+	if pt.Order() != 7 {
+		panic(fmt.Sprintf("all vertexes can reach 5,but post-dominator tree has %d vertexes", pt.Order()))
+	}
+
+	df, err := DominanceFrontiers(g, 0)
+	if err != nil {
+		panic(err.Error())
+	}
+	expectDF := map[int][]int{0: {}, 1: {1}, 2: {4}, 3: {4}, 4: {1}, 5: {}}
+	for v, fs := range expectDF {
+		got := df[v]
+		sort.Ints(got)
+		if fmt.Sprint(got) != fmt.Sprint(fs) {
+			panic(fmt.Sprintf("dominance frontier of %d should be %v,but get %v", v, fs, got))
+		}
+	}
+}
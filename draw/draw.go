@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"os/exec"
 	"strings"
@@ -257,3 +258,148 @@ func RenderSVG[K comparable, W number](g graphlib.Graph[K, W], vertexShape strin
 	}
 	return svg, nil
 }
+
+type layoutSVGNode struct {
+	X, Y, R float64
+	Color   string
+	Label   string
+}
+
+type layoutSVGEdge struct {
+	X1, Y1, X2, Y2 float64
+	LX, LY, R      float64
+	Loop           bool
+	Color          string
+	Label          string
+}
+
+type layoutSVG struct {
+	Digraph       bool
+	Width, Height float64
+	Nodes         []layoutSVGNode
+	Edges         []layoutSVGEdge
+}
+
+const (
+	layoutSVGUnit   = 40.0
+	layoutSVGMargin = 30.0
+	layoutSVGRadius = 12.0
+)
+
+// Get the SVG of a planar graph, vertexes are placed by graphlib.PlanarLayout so that no two edges cross,
+// an error will be returned if the graph is not planar. Parallel edges are drawn as the same segment.
+func GetPlanarSVG[K comparable, W number](g graphlib.Graph[K, W], showWeight bool) ([]byte, error) {
+	pos, err := graphlib.PlanarLayout(g)
+	if err != nil {
+		return nil, err
+	}
+	return getLayoutSVG(g, pos, layoutSVGUnit, showWeight)
+}
+
+// Get the SVG of a graph, vertexes are placed by graphlib.SpectralLayout with the unweighted Laplacian matrix.
+// The drawing is scaled to fit in a square of side size pixels (without margin).
+func GetSpectralSVG[K comparable, W number](g graphlib.Graph[K, W], size float64, showWeight bool) ([]byte, error) {
+	pos, err := graphlib.SpectralLayout(g, false)
+	if err != nil {
+		return nil, err
+	}
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, xy := range pos {
+		minX, minY = min(minX, xy[0]), min(minY, xy[1])
+		maxX, maxY = max(maxX, xy[0]), max(maxY, xy[1])
+	}
+	unit := size
+	if d := max(maxX-minX, maxY-minY); d > 0 {
+		unit = size / d
+	}
+	return getLayoutSVG(g, pos, unit, showWeight)
+}
+
+// getLayoutSVG draws every edge as a straight segment between the given vertex positions,
+// the positions are shifted to the origin and a unit of length takes unit pixels.
+func getLayoutSVG[K comparable, W number](g graphlib.Graph[K, W], pos map[K][2]float64, unit float64, showWeight bool) ([]byte, error) {
+	minX, minY, maxX, maxY := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, xy := range pos {
+		minX, minY = min(minX, xy[0]), min(minY, xy[1])
+		maxX, maxY = max(maxX, xy[0]), max(maxY, xy[1])
+	}
+	if len(pos) == 0 {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	// the y axis of SVG points down.
+	point := func(k K) (float64, float64) {
+		xy := pos[k]
+		return layoutSVGMargin + (xy[0]-minX)*unit, layoutSVGMargin + (maxY-xy[1])*unit
+	}
+	svg := &layoutSVG{
+		Digraph: g.IsDigraph(),
+		Width:   2*layoutSVGMargin + (maxX-minX)*unit,
+		Height:  2*layoutSVGMargin + (maxY-minY)*unit,
+	}
+	for _, v := range g.AllVertexes() {
+		x, y := point(v.Key)
+		node := layoutSVGNode{X: x, Y: y, R: layoutSVGRadius, Color: "black", Label: template.HTMLEscapeString(fmt.Sprintf("%v", v.Key))}
+		if c := v.Labels["color"]; c != "" {
+			node.Color = c
+		}
+		svg.Nodes = append(svg.Nodes, node)
+	}
+	for _, e := range g.AllEdges() {
+		x1, y1 := point(e.Tail)
+		x2, y2 := point(e.Head)
+		edge := layoutSVGEdge{X1: x1, Y1: y1, X2: x2, Y2: y2, Color: "#666"}
+		if c := e.Labels["color"]; c != "" {
+			edge.Color = c
+		}
+		if d := math.Hypot(x2-x1, y2-y1); e.Tail == e.Head || d == 0 {
+			// a loop is a small circle above the vertex, so is an edge whose ends coincide.
+			edge.Loop, edge.R = true, layoutSVGRadius
+			edge.Y1 -= layoutSVGRadius
+			edge.LX, edge.LY = x1, y1-3*layoutSVGRadius
+		} else {
+			// stop at the border of the vertex so that the arrow can be seen.
+			edge.X2 -= (x2 - x1) / d * layoutSVGRadius
+			edge.Y2 -= (y2 - y1) / d * layoutSVGRadius
+			edge.LX, edge.LY = (x1+x2)/2, (y1+y2)/2
+		}
+		if showWeight {
+			edge.Label = template.HTMLEscapeString(fmt.Sprintf("%v", e.Weight))
+		}
+		svg.Edges = append(svg.Edges, edge)
+	}
+	tpl, err := template.New(g.Name()).Parse(layoutSVGTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tpl.Execute(&buf, svg); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Render a planar graph to the SVG file dir/name.svg without edge crossings, Graphviz is not needed.
+func RenderPlanarSVG[K comparable, W number](g graphlib.Graph[K, W], showWeight bool, dir string) (string, error) {
+	svg, err := GetPlanarSVG(g, showWeight)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s/%s.svg", dir, g.Name())
+	if err = os.WriteFile(name, svg, 0666); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// Render a graph to the SVG file dir/name.svg with the spectral layout, Graphviz is not needed.
+func RenderSpectralSVG[K comparable, W number](g graphlib.Graph[K, W], size float64, showWeight bool, dir string) (string, error) {
+	svg, err := GetSpectralSVG(g, size, showWeight)
+	if err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s/%s.svg", dir, g.Name())
+	if err = os.WriteFile(name, svg, 0666); err != nil {
+		return "", err
+	}
+	return name, nil
+}
@@ -19,6 +19,7 @@ package draw
 import (
 	"flag"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/flxj/graphlib"
@@ -193,3 +194,52 @@ func TestDraw(t *testing.T) {
 	default:
 	}
 }
+
+func TestPlanarSVG(t *testing.T) {
+	g := graphlib.Hypercube(3)
+	_ = g.SetVertexLabel(0, "color", "red")
+	svg, err := GetPlanarSVG(g, true)
+	if err != nil {
+		panic(err.Error())
+	}
+	s := string(svg)
+	if n := strings.Count(s, "<line "); n != g.Size() {
+		panic(fmt.Sprintf("expect %d edges, get %d", g.Size(), n))
+	}
+	if n := strings.Count(s, "<circle "); n != g.Order() {
+		panic(fmt.Sprintf("expect %d vertexes, get %d", g.Order(), n))
+	}
+	if !strings.Contains(s, `stroke="red"`) {
+		panic("vertex color is lost")
+	}
+	file, err := RenderPlanarSVG(graphlib.CompleteGraph(4), false, t.TempDir())
+	if err != nil {
+		panic(err.Error())
+	}
+	fmt.Println(file)
+	if _, err = GetPlanarSVG(graphlib.CompleteGraph(5), false); err == nil {
+		panic("K5 is not planar")
+	}
+	fmt.Println("=======> test planar svg pass")
+}
+
+func TestSpectralSVG(t *testing.T) {
+	g := graphlib.PetersenGraph()
+	svg, err := GetSpectralSVG(g, 400, false)
+	if err != nil {
+		panic(err.Error())
+	}
+	s := string(svg)
+	if n := strings.Count(s, "<line "); n != g.Size() {
+		panic(fmt.Sprintf("expect %d edges, get %d", g.Size(), n))
+	}
+	if n := strings.Count(s, "<circle "); n != g.Order() {
+		panic(fmt.Sprintf("expect %d vertexes, get %d", g.Order(), n))
+	}
+	file, err := RenderSpectralSVG(graphlib.Hypercube(4), 400, true, t.TempDir())
+	if err != nil {
+		panic(err.Error())
+	}
+	fmt.Println(file)
+	fmt.Println("=======> test spectral svg pass")
+}
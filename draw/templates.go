@@ -135,3 +135,32 @@ const dotTemplate = `
     {{end}}
 }
 `
+
+const layoutSVGTemplate = `<svg xmlns="http://www.w3.org/2000/svg" width="{{.Width}}" height="{{.Height}}" viewBox="0 0 {{.Width}} {{.Height}}">
+{{- if .Digraph}}
+  <defs>
+    <marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto-start-reverse">
+      <path d="M 0 0 L 10 5 L 0 10 z" fill="#666"/>
+    </marker>
+  </defs>
+{{- end}}
+  <g stroke-width="1.5" fill="none">
+{{- range .Edges}}
+  {{- if .Loop}}
+    <circle cx="{{.X1}}" cy="{{.Y1}}" r="{{.R}}" stroke="{{.Color}}"/>
+  {{- else}}
+    <line x1="{{.X1}}" y1="{{.Y1}}" x2="{{.X2}}" y2="{{.Y2}}" stroke="{{.Color}}"{{if $.Digraph}} marker-end="url(#arrow)"{{end}}/>
+  {{- end}}
+  {{- if .Label}}
+    <text x="{{.LX}}" y="{{.LY}}" font-size="10" fill="#333" stroke="none">{{.Label}}</text>
+  {{- end}}
+{{- end}}
+  </g>
+  <g font-size="12" text-anchor="middle" dominant-baseline="central">
+{{- range .Nodes}}
+    <circle cx="{{.X}}" cy="{{.Y}}" r="{{.R}}" fill="white" stroke="{{.Color}}" stroke-width="1.5"/>
+    <text x="{{.X}}" y="{{.Y}}">{{.Label}}</text>
+{{- end}}
+  </g>
+</svg>
+`
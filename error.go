@@ -40,6 +40,22 @@ var (
 	errMatchNotExists   = errors.New("perfect matching not exists")
 	errNoColouring      = errors.New("proper colouring not exists")
 	errEmptyHyperEdge   = errors.New("the hyperedge is empty")
+	errNegativeWeight   = errors.New("current graph contains negative weight edges")
+	errNotConverged     = errors.New("the iteration does not converge")
+	errInvalidPartition = errors.New("every vertex should belong to exactly one part of the partition")
+	errAdjacentVertexes = errors.New("the two vertexes are adjacent")
+	errNoArborescence   = errors.New("spanning arborescence not exists")
+	errDigraph          = errors.New("current graph is digraph")
+	errNoEulerianPath   = errors.New("eulerian path not exists")
+	errNoEulerianCycle  = errors.New("eulerian circuit not exists")
+	errNoHamiltonPath   = errors.New("hamiltonian path not exists")
+	errNoHamiltonCycle  = errors.New("hamiltonian cycle not exists")
+	errTooManyVertexes  = errors.New("too many vertexes for the exact algorithm")
+	errNotChordal       = errors.New("current graph is not chordal")
+	errNotSplit         = errors.New("current graph is not split graph")
+	errNotPlanar        = errors.New("current graph is not planar")
+	errNotSymmetric     = errors.New("the matrix is not symmetric")
+	errShapeMismatch    = errors.New("the shapes of matrices do not match")
 	errNone             = errors.New("")
 )
 
@@ -0,0 +1,348 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "math"
+
+// eulerLink is an edge of the multigraph walked by Hierholzer algorithm,
+// edge is the index of the original edge, a postman tour may contain several links of the same edge.
+type eulerLink struct {
+	tail, head, edge int
+}
+
+func (g *indexedGraph[K, W]) eulerLinks() []eulerLink {
+	links := make([]eulerLink, len(g.edges))
+	for i, e := range g.edges {
+		links[i] = eulerLink{tail: g.idx[e.Tail], head: g.idx[e.Head], edge: i}
+	}
+	return links
+}
+
+// eulerDegrees returns the in-degree and out-degree of every vertex,
+// for undirected graph both are the degree (a loop is counted twice).
+func eulerDegrees(n int, digraph bool, links []eulerLink) ([]int, []int) {
+	in, out := make([]int, n), make([]int, n)
+	for _, l := range links {
+		out[l.tail]++
+		in[l.head]++
+	}
+	if digraph {
+		return in, out
+	}
+	for v := 0; v < n; v++ {
+		out[v] += in[v]
+	}
+	return out, out
+}
+
+// eulerStart checks the degree conditions and returns the start vertex of an eulerian circuit (or path).
+//
+// A connected graph has an eulerian circuit iff every vertex has even degree,
+// and has an eulerian path iff there are zero or two vertexes with odd degree.
+// A connected digraph has an eulerian circuit iff indegree equals outdegree for every vertex,
+// and has an eulerian path iff at most one vertex has outdegree-indegree=1 (the start),
+// at most one vertex has indegree-outdegree=1 and all others are balanced.
+func eulerStart(n int, digraph bool, links []eulerLink, circuit bool) (int, bool) {
+	in, out := eulerDegrees(n, digraph, links)
+	start, begin, end := -1, 0, 0
+	for v := 0; v < n; v++ {
+		if digraph {
+			switch d := out[v] - in[v]; {
+			case d == 0:
+				continue
+			case d == 1:
+				start = v
+				begin++
+			case d == -1:
+				end++
+			default:
+				return 0, false
+			}
+		} else if out[v]%2 == 1 {
+			if start < 0 {
+				start = v
+			}
+			begin++
+		}
+	}
+	if circuit && begin > 0 {
+		return 0, false
+	}
+	if (digraph && (begin > 1 || end > 1)) || (!digraph && begin > 2) {
+		return 0, false
+	}
+	if start >= 0 {
+		return start, true
+	}
+	// all the vertexes are balanced, start from any vertex with edges.
+	for v := 0; v < n; v++ {
+		if out[v] > 0 {
+			return v, true
+		}
+	}
+	return 0, true
+}
+
+/*
+Hierholzer algorithm, find an eulerian trail from start in O(m) time.
+
+Follow unused edges from start until getting stuck, the stuck vertex is put at the front of the trail,
+then backtrack to the latest vertex which still has unused edges and continue from it,
+the sub-circuits found in this way are spliced into the trail.
+
+Return the links in the order of the trail, and whether all the links have been walked
+(if not, the edges are not connected).
+*/
+func hierholzer(n int, digraph bool, links []eulerLink, start int) ([]int, bool) {
+	type step struct {
+		to, link int
+	}
+	adj := make([][]step, n)
+	for i, l := range links {
+		adj[l.tail] = append(adj[l.tail], step{to: l.head, link: i})
+		if !digraph && l.tail != l.head {
+			adj[l.head] = append(adj[l.head], step{to: l.tail, link: i})
+		}
+	}
+	used := make([]bool, len(links))
+	ptr := make([]int, n)
+	stk := []step{{to: start, link: -1}}
+	walk := make([]int, 0, len(links))
+	for len(stk) > 0 {
+		top := stk[len(stk)-1]
+		v := top.to
+		for ptr[v] < len(adj[v]) && used[adj[v][ptr[v]].link] {
+			ptr[v]++
+		}
+		if ptr[v] == len(adj[v]) {
+			stk = stk[:len(stk)-1]
+			if top.link >= 0 {
+				walk = append(walk, top.link)
+			}
+			continue
+		}
+		s := adj[v][ptr[v]]
+		used[s.link] = true
+		stk = append(stk, s)
+	}
+	for i, j := 0, len(walk)-1; i < j; i, j = i+1, j-1 {
+		walk[i], walk[j] = walk[j], walk[i]
+	}
+	return walk, len(walk) == len(links)
+}
+
+// eulerPath converts a trail of links into a Path.
+func (g *indexedGraph[K, W]) eulerPath(start int, links []eulerLink, walk []int) Path[K, W] {
+	p := Path[K, W]{
+		Source: g.keys[start],
+		Edges:  make([]K, 0, len(walk)),
+	}
+	cur := start
+	for _, i := range walk {
+		l := links[i]
+		e := g.edges[l.edge]
+		p.Edges = append(p.Edges, e.Key)
+		p.Weight += e.Weight
+		if l.tail == cur {
+			cur = l.head
+		} else {
+			cur = l.tail
+		}
+	}
+	p.Target = g.keys[cur]
+	return p
+}
+
+func eulerian[K comparable, W number](g Graph[K, W], circuit bool) (Path[K, W], error) {
+	notExists := errNoEulerianPath
+	if circuit {
+		notExists = errNoEulerianCycle
+	}
+	if g == nil {
+		return Path[K, W]{}, errNilGraph
+	}
+	if g.Order() == 0 {
+		return Path[K, W]{}, errEmptyGraph
+	}
+	ig := newIndexedGraph(g)
+	links := ig.eulerLinks()
+	start, ok := eulerStart(ig.order(), ig.digraph, links, circuit)
+	if !ok {
+		return Path[K, W]{}, notExists
+	}
+	walk, ok := hierholzer(ig.order(), ig.digraph, links, start)
+	if !ok {
+		return Path[K, W]{}, notExists
+	}
+	return ig.eulerPath(start, links, walk), nil
+}
+
+// Determine whether the graph is eulerian, i.e. there is a closed trail which uses every edge exactly once.
+// Isolated vertexes are ignored, g can be an undirected graph or a directed graph, and multiple edges are allowed.
+func IsEulerian[K comparable, W number](g Graph[K, W]) (bool, error) {
+	_, err := eulerian(g, true)
+	if err == errNoEulerianCycle {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Calculate an eulerian circuit of the graph, the edges of the returned path are in the order of the trail,
+// and Source equals Target. If the graph is not eulerian an error will be returned.
+func EulerianCircuit[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	return eulerian(g, true)
+}
+
+// Calculate an eulerian path of the graph, i.e. a trail which uses every edge exactly once.
+// If the graph is eulerian the returned path is a circuit.
+func EulerianPath[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	return eulerian(g, false)
+}
+
+// minWeightPairing pairs up the vertexes 0..k-1 (k is even) with minimum total cost, mate[i] is the partner of i.
+// It is a minimum weight perfect matching of the complete graph, solved by the weighted blossom algorithm in O(k^3) time.
+func minWeightPairing(cost [][]float64) []int {
+	k := len(cost)
+	var maxCost float64
+	for i := range cost {
+		for j := i + 1; j < k; j++ {
+			maxCost = math.Max(maxCost, cost[i][j])
+		}
+	}
+	edges := make([]weightedPair, 0, k*(k-1)/2)
+	for i := 0; i < k; i++ {
+		for j := i + 1; j < k; j++ {
+			edges = append(edges, weightedPair{i, j, maxCost + 1 - cost[i][j]})
+		}
+	}
+	mate := maxWeightMatching(k, edges, true)
+	for i, e := range mate {
+		if edges[e].i == i {
+			mate[i] = edges[e].j
+		} else {
+			mate[i] = edges[e].i
+		}
+	}
+	return mate
+}
+
+// appendPathLinks appends the links of the shortest path from the source of ss to v.
+func (g *indexedGraph[K, W]) appendPathLinks(links []eulerLink, ss *singleSource, v int) []eulerLink {
+	for len(ss.pred[v]) > 0 {
+		a := ss.pred[v][0]
+		links = append(links, eulerLink{tail: a.to, head: v, edge: a.edge})
+		v = a.to
+	}
+	return links
+}
+
+/*
+Chinese postman problem (route inspection problem): find a shortest closed walk which traverses every edge at least once.
+
+For undirected graph, the vertexes with odd degree are paired up by a minimum weight perfect matching
+where the weight of a pair is their shortest distance, then the shortest paths between the matched vertexes are
+duplicated, and every vertex in the augmented multigraph has even degree.
+
+For digraph, a vertex with indegree-outdegree=d>0 must be the start of d extra paths,
+and a vertex with outdegree-indegree=d>0 must be the end of d extra paths, the extra paths are
+found by an assignment problem (Hungarian algorithm) over the shortest distances.
+
+Finally an eulerian circuit of the augmented multigraph is the postman tour, the edges of the returned path
+are in the order of the tour and an edge may appear several times.
+Negative weights are not allowed, and if the edges are not (strongly) connected an error will be returned.
+*/
+func ChinesePostman[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	if g == nil {
+		return Path[K, W]{}, errNilGraph
+	}
+	if g.Order() == 0 {
+		return Path[K, W]{}, errEmptyGraph
+	}
+	ig := newIndexedGraph(g)
+	if ig.hasNegativeWeight() {
+		return Path[K, W]{}, errNegativeWeight
+	}
+	n := ig.order()
+	links := ig.eulerLinks()
+	in, out := eulerDegrees(n, ig.digraph, links)
+	if !ig.digraph {
+		var odd []int
+		for v := 0; v < n; v++ {
+			if out[v]%2 == 1 {
+				odd = append(odd, v)
+			}
+		}
+		sss := make([]*singleSource, len(odd))
+		cost := make([][]float64, len(odd))
+		for i, s := range odd {
+			sss[i] = ig.shortestPathDAG(s, true, true)
+			cost[i] = make([]float64, len(odd))
+			for j, t := range odd {
+				if sss[i].dist[t] < 0 {
+					return Path[K, W]{}, errNotConnected
+				}
+				cost[i][j] = sss[i].dist[t]
+			}
+		}
+		for i, j := range minWeightPairing(cost) {
+			if i < j {
+				links = ig.appendPathLinks(links, sss[i], odd[j])
+			}
+		}
+	} else {
+		// extra paths start from rows and end at columns.
+		var rows, cols []int
+		for v := 0; v < n; v++ {
+			for d := in[v] - out[v]; d > 0; d-- {
+				rows = append(rows, v)
+			}
+			for d := out[v] - in[v]; d > 0; d-- {
+				cols = append(cols, v)
+			}
+		}
+		if k := len(rows); k > 0 {
+			sss := make(map[int]*singleSource)
+			weight := make([][]float64, k+1)
+			weight[0] = make([]float64, k+1)
+			for i, s := range rows {
+				if _, ok := sss[s]; !ok {
+					sss[s] = ig.shortestPathDAG(s, true, true)
+				}
+				weight[i+1] = make([]float64, k+1)
+				for j, t := range cols {
+					if sss[s].dist[t] < 0 {
+						return Path[K, W]{}, errNotConnected
+					}
+					weight[i+1][j+1] = sss[s].dist[t]
+				}
+			}
+			res := minMatchingHungarian(k, weight)
+			for i := 1; i <= k; i++ {
+				links = ig.appendPathLinks(links, sss[rows[i-1]], cols[res[i]-1])
+			}
+		}
+	}
+	start, _ := eulerStart(n, ig.digraph, links, true)
+	walk, ok := hierholzer(n, ig.digraph, links, start)
+	if !ok {
+		return Path[K, W]{}, errNotConnected
+	}
+	return ig.eulerPath(start, links, walk), nil
+}
@@ -0,0 +1,258 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func testMultigraph(digraph bool, n int, edges [][3]int) Graph[int, int] {
+	g := NewGraph[int, int](digraph, "")
+	for i := 0; i < n; i++ {
+		if err := g.AddVertex(Vertex[int, int]{Key: i}); err != nil {
+			panic(err.Error())
+		}
+	}
+	for i, e := range edges {
+		if err := g.AddEdge(Edge[int, int]{Key: i, Tail: e[0], Head: e[1], Weight: e[2]}); err != nil {
+			panic(err.Error())
+		}
+	}
+	return g
+}
+
+// checkTrail verifies that p is a walk of g, and returns how many times every edge is used.
+func checkTrail(g Graph[int, int], p Path[int, int]) map[int]int {
+	used := make(map[int]int)
+	cur, w := p.Source, 0
+	for _, k := range p.Edges {
+		e, err := g.GetEdgeByKey(k)
+		if err != nil {
+			panic(err.Error())
+		}
+		switch {
+		case e.Tail == cur:
+			cur = e.Head
+		case e.Head == cur && !g.IsDigraph():
+			cur = e.Tail
+		default:
+			panic(fmt.Sprintf("edge %d is not incident to %d", k, cur))
+		}
+		used[k]++
+		w += e.Weight
+	}
+	if cur != p.Target || w != p.Weight {
+		panic(fmt.Sprintf("wrong target %d or weight %d of path %+v", cur, w, p))
+	}
+	return used
+}
+
+func TestEulerian(t *testing.T) {
+	// the seven bridges of Konigsberg.
+	k := [][3]int{{0, 1, 1}, {0, 1, 1}, {0, 2, 1}, {0, 2, 1}, {0, 3, 1}, {1, 3, 1}, {2, 3, 1}}
+	g := testMultigraph(false, 4, k)
+	if ok, err := IsEulerian(g); err != nil || ok {
+		panic(fmt.Sprintf("konigsberg is not eulerian, %v", err))
+	}
+	if _, err := EulerianPath(g); err != errNoEulerianPath {
+		panic(fmt.Sprintf("konigsberg has no eulerian path, %v", err))
+	}
+	// postman tour repeats the cheapest pairing of the four odd vertexes.
+	p, err := ChinesePostman(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if p.Weight != 9 || p.Source != p.Target || len(checkTrail(g, p)) != len(k) {
+		panic(fmt.Sprintf("wrong postman tour %+v", p))
+	}
+
+	// adding a bridge leaves only two odd vertexes.
+	g = testMultigraph(false, 5, append(k, [3]int{1, 2, 1}, [3]int{3, 3, 1}, [3]int{4, 4, 1}))
+	if _, err := EulerianCircuit(g); err != errNoEulerianCycle {
+		panic(fmt.Sprintf("no eulerian circuit, %v", err))
+	}
+	if _, err := EulerianPath(g); err != errNoEulerianPath {
+		panic(fmt.Sprintf("the loop at 4 is not connected, %v", err))
+	}
+	if err := g.RemoveVertex(4); err != nil {
+		panic(err.Error())
+	}
+	p, err = EulerianPath(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	used := checkTrail(g, p)
+	if len(used) != g.Size() || len(p.Edges) != g.Size() || p.Source == p.Target {
+		panic(fmt.Sprintf("wrong eulerian path %+v", p))
+	}
+	if !((p.Source == 0 && p.Target == 3) || (p.Source == 3 && p.Target == 0)) {
+		panic(fmt.Sprintf("eulerian path should connect 0 and 3, %+v", p))
+	}
+
+	// digraph with multiple arcs.
+	d := testMultigraph(true, 3, [][3]int{{0, 1, 1}, {1, 0, 1}, {0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {1, 1, 1}})
+	if ok, err := IsEulerian(d); err != nil || !ok {
+		panic(fmt.Sprintf("digraph should be eulerian, %v", err))
+	}
+	p, err = EulerianCircuit(d)
+	if err != nil {
+		panic(err.Error())
+	}
+	if used = checkTrail(d, p); len(used) != d.Size() || len(p.Edges) != d.Size() || p.Source != p.Target {
+		panic(fmt.Sprintf("wrong eulerian circuit %+v", p))
+	}
+	fmt.Println("=======> test eulerian pass")
+}
+
+func TestChinesePostman(t *testing.T) {
+	g := testMultigraph(false, 3, [][3]int{{0, 1, 1}, {1, 2, 2}})
+	p, err := ChinesePostman(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if p.Weight != 6 || checkTrail(g, p)[1] != 2 {
+		panic(fmt.Sprintf("wrong postman tour %+v", p))
+	}
+	// 2 has one more incoming arc, the tour needs an extra path 2->0.
+	d := testMultigraph(true, 3, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {0, 2, 5}})
+	if p, err = ChinesePostman(d); err != nil {
+		panic(err.Error())
+	}
+	if p.Weight != 9 || p.Source != p.Target || checkTrail(d, p)[2] != 2 {
+		panic(fmt.Sprintf("wrong postman tour %+v", p))
+	}
+	d = testMultigraph(true, 3, [][3]int{{0, 1, 1}, {1, 2, 1}})
+	if _, err = ChinesePostman(d); err != errNotConnected {
+		panic(fmt.Sprintf("digraph is not strongly connected, %v", err))
+	}
+	// many odd vertexes: the cycle vertexes with pendant edges and the pendant vertexes.
+	n := 44
+	var es [][3]int
+	for i := 0; i < n; i++ {
+		es = append(es, [3]int{i, (i + 1) % n, 1})
+	}
+	for i := 0; i < n; i += 2 {
+		es = append(es, [3]int{i, n + i/2, 1})
+	}
+	g = testMultigraph(false, n+n/2, es)
+	if p, err = ChinesePostman(g); err != nil {
+		panic(err.Error())
+	}
+	// every pendant edge is walked twice.
+	if p.Weight != n+n/2+n/2 {
+		panic(fmt.Sprintf("wrong postman tour weight %d", p.Weight))
+	}
+	checkTrail(g, p)
+	fmt.Println("=======> test chinese postman pass")
+}
+
+// bruteForcePairing calculates the minimum cost of pairing up the vertexes by dynamic programming over subsets.
+func bruteForcePairing(cost [][]int) int {
+	k := len(cost)
+	const inf = 1 << 60
+	dp := make([]int, 1<<k)
+	for m := 1; m < len(dp); m++ {
+		dp[m] = inf
+		i := 0
+		for m&(1<<i) == 0 {
+			i++
+		}
+		for j := i + 1; j < k; j++ {
+			if m&(1<<j) != 0 {
+				if c := dp[m&^(1<<i|1<<j)] + cost[i][j]; c < dp[m] {
+					dp[m] = c
+				}
+			}
+		}
+	}
+	return dp[len(dp)-1]
+}
+
+func TestChinesePostmanOptimal(t *testing.T) {
+	r := rand.New(rand.NewSource(38))
+	for tested := 0; tested < 3; {
+		// a random connected graph with weights 1..20.
+		n := 30
+		var es [][3]int
+		for i := 1; i < n; i++ {
+			es = append(es, [3]int{r.Intn(i), i, 1 + r.Intn(20)})
+		}
+		for i := 0; i < 15; i++ {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), 1 + r.Intn(20)})
+		}
+		deg := make([]int, n)
+		total := 0
+		for _, e := range es {
+			deg[e[0]]++
+			deg[e[1]]++
+			total += e[2]
+		}
+		var odd []int
+		for v := 0; v < n; v++ {
+			if deg[v]%2 == 1 {
+				odd = append(odd, v)
+			}
+		}
+		// more odd vertexes than a greedy pairing can handle reliably, but few enough for the subset DP.
+		if len(odd) != 22 {
+			continue
+		}
+		tested++
+		dist := make([][]int, n)
+		for i := range dist {
+			dist[i] = make([]int, n)
+			for j := range dist[i] {
+				if i != j {
+					dist[i][j] = 1 << 40
+				}
+			}
+		}
+		for _, e := range es {
+			if e[2] < dist[e[0]][e[1]] {
+				dist[e[0]][e[1]], dist[e[1]][e[0]] = e[2], e[2]
+			}
+		}
+		for k := 0; k < n; k++ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if d := dist[i][k] + dist[k][j]; d < dist[i][j] {
+						dist[i][j] = d
+					}
+				}
+			}
+		}
+		cost := make([][]int, len(odd))
+		for i, u := range odd {
+			cost[i] = make([]int, len(odd))
+			for j, v := range odd {
+				cost[i][j] = dist[u][v]
+			}
+		}
+		g := testMultigraph(false, n, es)
+		p, err := ChinesePostman(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if want := total + bruteForcePairing(cost); p.Weight != want || p.Source != p.Target {
+			panic(fmt.Sprintf("postman tour weight should be %d,but get %d", want, p.Weight))
+		}
+		checkTrail(g, p)
+	}
+	fmt.Println("=======> test optimal chinese postman pass")
+}
@@ -0,0 +1,466 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// the exact algorithms are used for strongly connected components up to this order.
+const feedbackExactLimit = 16
+
+/*
+Calculate a minimum feedback arc set of digraph, i.e. a smallest set of arcs whose removal makes the digraph acyclic,
+the keys of the arcs are returned. Loops always belong to the set, and every directed cycle lies in a strongly connected
+component, so the components are solved independently. A feedback arc set is the set of backward arcs of a linear
+ordering of vertexes: for components with at most 16 vertexes the best ordering is found by branch and bound,
+for larger components the Eades–Lin–Smyth heuristic gives the ordering, and arcs of the heuristic solution which
+close no cycle are put back, so the result is minimal but not necessarily minimum.
+*/
+func MinimumFeedbackArcSet[K comparable, W number](g Digraph[K, W]) ([]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	fas := ig.feedbackArcSet()
+	res := make([]K, len(fas))
+	for i, e := range fas {
+		res[i] = ig.edges[e].Key
+	}
+	return res, nil
+}
+
+/*
+Calculate a feedback vertex set of digraph, i.e. a set of vertexes whose removal makes the digraph acyclic.
+Vertexes with loops always belong to the set. A strongly connected component with at most 16 vertexes is solved exactly
+by checking the vertex subsets in increasing size, for larger components the vertex with the maximum product of
+in-degree and out-degree is removed repeatedly, and then every vertex which closes no cycle is put back.
+*/
+func FeedbackVertexSet[K comparable, W number](g Digraph[K, W]) ([]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	n := ig.order()
+	alive := make([]bool, n)
+	for v := range alive {
+		alive[v] = true
+	}
+	var fvs []int
+	for v := 0; v < n; v++ {
+		for _, a := range ig.out[v] {
+			if a.to == v && alive[v] {
+				alive[v] = false
+				fvs = append(fvs, v)
+			}
+		}
+	}
+	var greedy []int
+	for _, comp := range ig.strongComponents(alive) {
+		if len(comp) < 2 {
+			continue
+		}
+		if len(comp) <= feedbackExactLimit {
+			fvs = append(fvs, ig.exactFeedbackVertexSet(comp)...)
+			continue
+		}
+		// remove the vertex with maximum in-degree*out-degree from every non-trivial component until none remains.
+		for progress := true; progress; {
+			progress = false
+			cur := make([]bool, n)
+			for _, v := range comp {
+				cur[v] = alive[v]
+			}
+			for _, sub := range ig.strongComponents(cur) {
+				if len(sub) < 2 {
+					continue
+				}
+				best, bestScore := -1, -1
+				for _, v := range sub {
+					var in, out int
+					for _, a := range ig.out[v] {
+						if cur[a.to] {
+							out++
+						}
+					}
+					for _, a := range ig.in[v] {
+						if cur[a.to] {
+							in++
+						}
+					}
+					if in*out > bestScore {
+						best, bestScore = v, in*out
+					}
+				}
+				alive[best], progress = false, true
+				greedy = append(greedy, best)
+			}
+		}
+	}
+	// put back the vertexes which close no cycle, the latest removed first.
+	for i := len(greedy) - 1; i >= 0; i-- {
+		v := greedy[i]
+		alive[v] = true
+		if !ig.reachable(ig.out[v], v, func(a arc) bool { return alive[a.to] }) {
+			continue
+		}
+		alive[v] = false
+		fvs = append(fvs, v)
+	}
+	res := make([]K, len(fvs))
+	for i, v := range fvs {
+		res[i] = ig.keys[v]
+	}
+	return res, nil
+}
+
+// feedbackArcSet returns a minimal feedback arc set, minimum if every strongly connected component is small.
+func (g *indexedGraph[K, W]) feedbackArcSet() []int {
+	n := g.order()
+	pos := make([]int, n)
+	var next int
+	exact := true
+	for _, comp := range g.strongComponents(nil) {
+		local := make(map[int]int, len(comp))
+		for i, v := range comp {
+			local[v] = i
+		}
+		out := make([][]int, len(comp))
+		in := make([][]int, len(comp))
+		for i, v := range comp {
+			for _, a := range g.out[v] {
+				if j, ok := local[a.to]; ok && j != i {
+					out[i] = append(out[i], j)
+					in[j] = append(in[j], i)
+				}
+			}
+		}
+		order := feedbackOrderELS(out, in)
+		if len(comp) <= feedbackExactLimit {
+			order = feedbackOrderExact(out, order)
+		} else {
+			exact = false
+		}
+		for _, i := range order {
+			pos[comp[i]] = next
+			next++
+		}
+	}
+	var fas []int
+	removed := make([]bool, len(g.edges))
+	for i, e := range g.edges {
+		if t, h := g.idx[e.Tail], g.idx[e.Head]; pos[t] >= pos[h] {
+			fas = append(fas, i)
+			removed[i] = true
+		}
+	}
+	if exact {
+		return fas
+	}
+	// put back the arcs which close no cycle.
+	res := fas[:0]
+	for _, e := range fas {
+		t, h := g.idx[g.edges[e].Tail], g.idx[g.edges[e].Head]
+		removed[e] = false
+		if t == h || g.reachable([]arc{{to: h, edge: e}}, t, func(a arc) bool { return !removed[a.edge] }) {
+			removed[e] = true
+			res = append(res, e)
+		}
+	}
+	return res
+}
+
+/*
+Eades–Lin–Smyth heuristic for the linear ordering with few backward arcs: sinks are moved to the end and sources to the front,
+when neither exists the vertex with maximum out-degree minus in-degree is moved to the front.
+The number of backward arcs is at most m/2 - n/6, and it runs in O(m log n) with a lazy heap.
+*/
+func feedbackOrderELS(out, in [][]int) []int {
+	n := len(out)
+	indeg, outdeg := make([]int, n), make([]int, n)
+	removed := make([]bool, n)
+	var sinks, sources []int
+	pq := NewPriorityQueue[int, int](func(p1, p2 int) bool { return p1 > p2 })
+	touch := func(v int) {
+		if outdeg[v] == 0 {
+			sinks = append(sinks, v)
+		} else if indeg[v] == 0 {
+			sources = append(sources, v)
+		}
+		pq.Push(v, outdeg[v]-indeg[v])
+	}
+	for v := 0; v < n; v++ {
+		indeg[v], outdeg[v] = len(in[v]), len(out[v])
+		touch(v)
+	}
+	var s1, s2 []int
+	remove := func(v int) {
+		removed[v] = true
+		for _, w := range out[v] {
+			if !removed[w] {
+				indeg[w]--
+				touch(w)
+			}
+		}
+		for _, u := range in[v] {
+			if !removed[u] {
+				outdeg[u]--
+				touch(u)
+			}
+		}
+	}
+	for len(s1)+len(s2) < n {
+		var v int
+		switch {
+		case len(sinks) != 0:
+			v, sinks = sinks[len(sinks)-1], sinks[:len(sinks)-1]
+			if !removed[v] {
+				s2 = append(s2, v)
+				remove(v)
+			}
+		case len(sources) != 0:
+			v, sources = sources[len(sources)-1], sources[:len(sources)-1]
+			if !removed[v] {
+				s1 = append(s1, v)
+				remove(v)
+			}
+		default:
+			// skip the outdated entries of the heap.
+			v, d, _ := pq.Pop()
+			if !removed[v] && d == outdeg[v]-indeg[v] {
+				s1 = append(s1, v)
+				remove(v)
+			}
+		}
+	}
+	for i := len(s2) - 1; i >= 0; i-- {
+		s1 = append(s1, s2[i])
+	}
+	return s1
+}
+
+/*
+Find the linear ordering with the minimum number of backward arcs by branch and bound, init is the initial solution.
+Vertexes are placed from left to right, placing v costs the number of arcs from the unplaced vertexes to v.
+For the unplaced vertexes every pair u,v costs at least min(w(u,v), w(v,u)), which gives the lower bound,
+and a placed set reached with no smaller cost than before is pruned.
+*/
+func feedbackOrderExact(out [][]int, init []int) []int {
+	n := len(out)
+	w := make([][]int, n)
+	for u := range w {
+		w[u] = make([]int, n)
+	}
+	for u := range out {
+		for _, v := range out[u] {
+			w[u][v]++
+		}
+	}
+	cost := func(order []int) int {
+		var c int
+		for i := range order {
+			for j := i + 1; j < n; j++ {
+				c += w[order[j]][order[i]]
+			}
+		}
+		return c
+	}
+	best, bestOrder := cost(init), append([]int{}, init...)
+	full := uint32(1)<<n - 1
+	seen := make(map[uint32]int)
+	order := make([]int, 0, n)
+	var search func(placed uint32, c int)
+	search = func(placed uint32, c int) {
+		if placed == full {
+			if c < best {
+				best = c
+				copy(bestOrder, order)
+			}
+			return
+		}
+		if old, ok := seen[placed]; ok && old <= c {
+			return
+		}
+		seen[placed] = c
+		rest := full &^ placed
+		bound := c
+		for r := rest; r != 0; r &= r - 1 {
+			u := bits.TrailingZeros32(r)
+			for s := r & (r - 1); s != 0; s &= s - 1 {
+				v := bits.TrailingZeros32(s)
+				bound += min(w[u][v], w[v][u])
+			}
+		}
+		if bound >= best {
+			return
+		}
+		type choice struct{ v, cost int }
+		var choices []choice
+		for r := rest; r != 0; r &= r - 1 {
+			v := bits.TrailingZeros32(r)
+			var d int
+			for s := rest &^ (1 << v); s != 0; s &= s - 1 {
+				d += w[bits.TrailingZeros32(s)][v]
+			}
+			choices = append(choices, choice{v, d})
+		}
+		sort.SliceStable(choices, func(i, j int) bool { return choices[i].cost < choices[j].cost })
+		for _, ch := range choices {
+			order = append(order, ch.v)
+			search(placed|1<<ch.v, c+ch.cost)
+			order = order[:len(order)-1]
+		}
+	}
+	search(0, 0)
+	return bestOrder
+}
+
+// exactFeedbackVertexSet checks the vertex subsets of a strongly connected component without loops in increasing size.
+func (g *indexedGraph[K, W]) exactFeedbackVertexSet(comp []int) []int {
+	n := len(comp)
+	local := make(map[int]int, n)
+	for i, v := range comp {
+		local[v] = i
+	}
+	out := make([]uint32, n)
+	for i, v := range comp {
+		for _, a := range g.out[v] {
+			if j, ok := local[a.to]; ok {
+				out[i] |= 1 << j
+			}
+		}
+	}
+	acyclic := func(alive uint32) bool {
+		// repeatedly remove the vertexes without out-arcs.
+		for alive != 0 {
+			sinks := uint32(0)
+			for r := alive; r != 0; r &= r - 1 {
+				if v := bits.TrailingZeros32(r); out[v]&alive == 0 {
+					sinks |= 1 << v
+				}
+			}
+			if sinks == 0 {
+				return false
+			}
+			alive &^= sinks
+		}
+		return true
+	}
+	full := uint32(1)<<n - 1
+	for k := 1; k < n; k++ {
+		// enumerate the subsets of size k by Gosper's hack.
+		for s := uint32(1)<<k - 1; s <= full; {
+			if acyclic(full &^ s) {
+				var res []int
+				for r := s; r != 0; r &= r - 1 {
+					res = append(res, comp[bits.TrailingZeros32(r)])
+				}
+				return res
+			}
+			c := s & -s
+			r := s + c
+			s = (((r ^ s) >> 2) / c) | r
+		}
+	}
+	return nil
+}
+
+// strongComponents returns the strongly connected components of the vertexes v with alive[v] (all if alive is nil)
+// by Kosaraju's algorithm, the components are in topological order.
+func (g *indexedGraph[K, W]) strongComponents(alive []bool) [][]int {
+	n := g.order()
+	ok := func(v int) bool { return alive == nil || alive[v] }
+	visited := make([]bool, n)
+	var finish []int
+	type frame struct{ v, i int }
+	for s := 0; s < n; s++ {
+		if visited[s] || !ok(s) {
+			continue
+		}
+		visited[s] = true
+		stack := []frame{{s, 0}}
+		for len(stack) != 0 {
+			f := &stack[len(stack)-1]
+			if f.i < len(g.out[f.v]) {
+				w := g.out[f.v][f.i].to
+				f.i++
+				if !visited[w] && ok(w) {
+					visited[w] = true
+					stack = append(stack, frame{w, 0})
+				}
+				continue
+			}
+			finish = append(finish, f.v)
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for v := range visited {
+		visited[v] = false
+	}
+	var comps [][]int
+	for i := len(finish) - 1; i >= 0; i-- {
+		s := finish[i]
+		if visited[s] {
+			continue
+		}
+		visited[s] = true
+		comp := []int{s}
+		for j := 0; j < len(comp); j++ {
+			for _, a := range g.in[comp[j]] {
+				if !visited[a.to] && ok(a.to) {
+					visited[a.to] = true
+					comp = append(comp, a.to)
+				}
+			}
+		}
+		comps = append(comps, comp)
+	}
+	return comps
+}
+
+// reachable reports whether target can be reached from the heads of start by the arcs satisfying use.
+func (g *indexedGraph[K, W]) reachable(start []arc, target int, use func(arc) bool) bool {
+	visited := make(map[int]bool)
+	var stack []int
+	for _, a := range start {
+		if use(a) && !visited[a.to] {
+			visited[a.to] = true
+			stack = append(stack, a.to)
+		}
+	}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if v == target {
+			return true
+		}
+		for _, a := range g.out[v] {
+			if use(a) && !visited[a.to] {
+				visited[a.to] = true
+				stack = append(stack, a.to)
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,221 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// acyclicWithout reports whether g becomes acyclic after removing the edges and vertexes (by Kahn's algorithm).
+func acyclicWithout(g Graph[int, int], edges, vertexes []int) bool {
+	skipEdge := make(map[int]bool)
+	for _, e := range edges {
+		skipEdge[e] = true
+	}
+	skipVertex := make(map[int]bool)
+	for _, v := range vertexes {
+		skipVertex[v] = true
+	}
+	indeg := make(map[int]int)
+	out := make(map[int][]int)
+	for _, e := range g.AllEdges() {
+		if !skipEdge[e.Key] && !skipVertex[e.Tail] && !skipVertex[e.Head] {
+			indeg[e.Head]++
+			out[e.Tail] = append(out[e.Tail], e.Head)
+		}
+	}
+	var stack []int
+	var count int
+	for _, v := range g.AllVertexes() {
+		if !skipVertex[v.Key] {
+			count++
+			if indeg[v.Key] == 0 {
+				stack = append(stack, v.Key)
+			}
+		}
+	}
+	for len(stack) != 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		count--
+		for _, w := range out[v] {
+			if indeg[w]--; indeg[w] == 0 {
+				stack = append(stack, w)
+			}
+		}
+	}
+	return count == 0
+}
+
+// bruteForceFeedbackArcSet tries all linear orderings.
+func bruteForceFeedbackArcSet(n int, es [][3]int) int {
+	best := len(es)
+	perm := make([]int, n)
+	pos := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	var permute func(k int)
+	permute = func(k int) {
+		if k == n {
+			for i, v := range perm {
+				pos[v] = i
+			}
+			var c int
+			for _, e := range es {
+				if pos[e[0]] >= pos[e[1]] {
+					c++
+				}
+			}
+			best = min(best, c)
+			return
+		}
+		for i := k; i < n; i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			permute(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	permute(0)
+	return best
+}
+
+// bruteForceFeedbackVertexSet tries all vertex subsets.
+func bruteForceFeedbackVertexSet(g Graph[int, int], n int) int {
+	best := n
+	for s := 0; s < 1<<n; s++ {
+		var vs []int
+		for v := 0; v < n; v++ {
+			if s&(1<<v) != 0 {
+				vs = append(vs, v)
+			}
+		}
+		if len(vs) < best && acyclicWithout(g, nil, vs) {
+			best = len(vs)
+		}
+	}
+	return best
+}
+
+func TestFeedbackSet(t *testing.T) {
+	r := rand.New(rand.NewSource(49))
+	for round := 0; round < 200; round++ {
+		n := 1 + r.Intn(7)
+		var es [][3]int
+		for k := r.Intn(3 * n); k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), 1})
+		}
+		g := testMultigraph(true, n, es).(Digraph[int, int])
+		fas, err := MinimumFeedbackArcSet(g)
+		if err != nil {
+			panic(err)
+		}
+		if want := bruteForceFeedbackArcSet(n, es); len(fas) != want || !acyclicWithout(g, fas, nil) {
+			panic(fmt.Sprintf("feedback arc set of %v: expect %d arcs, got %v", es, want, fas))
+		}
+		fvs, err := FeedbackVertexSet(g)
+		if err != nil {
+			panic(err)
+		}
+		if want := bruteForceFeedbackVertexSet(g, n); len(fvs) != want || !acyclicWithout(g, nil, fvs) {
+			panic(fmt.Sprintf("feedback vertex set of %v: expect %d vertexes, got %v", es, want, fvs))
+		}
+	}
+
+	// the heuristic results are minimal.
+	for round := 0; round < 5; round++ {
+		n := 40 + r.Intn(40)
+		var es [][3]int
+		for k := 3 * n; k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), 1})
+		}
+		g := testMultigraph(true, n, es).(Digraph[int, int])
+		fas, err := MinimumFeedbackArcSet(g)
+		if err != nil {
+			panic(err)
+		}
+		if !acyclicWithout(g, fas, nil) {
+			panic("the graph is still cyclic")
+		}
+		for i := range fas {
+			if acyclicWithout(g, append(append([]int{}, fas[:i]...), fas[i+1:]...), nil) {
+				panic(fmt.Sprintf("arc %d is not necessary", fas[i]))
+			}
+		}
+		fvs, err := FeedbackVertexSet(g)
+		if err != nil {
+			panic(err)
+		}
+		if !acyclicWithout(g, nil, fvs) {
+			panic("the graph is still cyclic")
+		}
+		for i := range fvs {
+			if acyclicWithout(g, nil, append(append([]int{}, fvs[:i]...), fvs[i+1:]...)) {
+				panic(fmt.Sprintf("vertex %d is not necessary", fvs[i]))
+			}
+		}
+	}
+	if _, err := MinimumFeedbackArcSet(testMultigraph(false, 1, nil).(Digraph[int, int])); err != errNotDigraph {
+		panic("expect errNotDigraph")
+	}
+	fmt.Println("=======> test feedback set pass")
+}
+
+func TestDetectCycle(t *testing.T) {
+	g := testMultigraph(true, 4, [][3]int{{0, 1, 1}, {1, 2, 1}, {0, 2, 1}, {2, 3, 1}})
+	cycles, err := g.(Digraph[int, int]).DetectCycle()
+	if err != nil || cycles != nil {
+		panic(fmt.Sprintf("expect no cycle, got %v %v", cycles, err))
+	}
+	r := rand.New(rand.NewSource(490))
+	for round := 0; round < 100; round++ {
+		n := 2 + r.Intn(30)
+		var es [][3]int
+		for k := 2 * n; k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), 1})
+		}
+		g := testMultigraph(true, n, es).(Digraph[int, int])
+		cycles, err := g.DetectCycle()
+		if err != nil {
+			panic(err)
+		}
+		var first []int
+		for _, c := range cycles {
+			first = append(first, c[0])
+			start := es[c[0]][0]
+			v := start
+			for _, e := range c {
+				if es[e][0] != v {
+					panic(fmt.Sprintf("%v is not a directed trail", c))
+				}
+				v = es[e][1]
+			}
+			if v != start {
+				panic(fmt.Sprintf("%v is not closed", c))
+			}
+		}
+		if !acyclicWithout(g, first, nil) {
+			panic("the graph is still cyclic")
+		}
+		if len(cycles) == 0 && !acyclicWithout(g, nil, nil) {
+			panic("cycles are not detected")
+		}
+	}
+	fmt.Println("=======> test detect cycle pass")
+}
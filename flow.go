@@ -111,7 +111,8 @@ func send[K comparable, W number](vertexes []K, flows, capacity [][]W, level map
 	if s == t {
 		return f
 	}
-	for p := start[s]; p < len(vertexes) && capacity[s][p] > 0; p++ {
+	// reverse arcs have zero capacity but positive residual capacity, so don't stop at zero capacity.
+	for p := start[s]; p < len(vertexes); p++ {
 		//
 		if level[p] == level[s]+1 && flows[s][p] < capacity[s][p] {
 			sendFlow := min(f, capacity[s][p]-flows[s][p])
@@ -145,13 +146,20 @@ func send[K comparable, W number](vertexes []K, flows, capacity [][]W, level map
 // (in terms of number of edges) of the node from source.
 // Once level graph is constructed, we send multiple flows using this level graph.
 func mfDinic[K comparable, W number](g Graph[K, W], source, sink K) (W, error) {
+	flow, _, err := dinic(g, source, sink)
+	return flow, err
+}
+
+// dinic returns the maximum flow and the source side of a minimum cut,
+// i.e. the vertexes reachable from source in the final residual graph.
+func dinic[K comparable, W number](g Graph[K, W], source, sink K) (W, []K, error) {
 	var (
 		flow     W
 		from, to int
 	)
 	wm, err := NewWeightMatrix(g)
 	if err != nil {
-		return flow, err
+		return flow, nil, err
 	}
 	vertexes := wm.Columns()
 	index := make(map[K]int)
@@ -170,32 +178,27 @@ func mfDinic[K comparable, W number](g Graph[K, W], source, sink K) (W, error) {
 	}
 	//
 	level := make(map[int]int)
-	buildLevel := func(s, t int) (bool, error) {
+	// bfs on the residual graph, the residual graph may contain reverse arcs which are not in g.
+	buildLevel := func(s, t int) bool {
 		for i := 0; i < len(vertexes); i++ {
 			level[i] = -1
 		}
 		level[s] = 0
-
-		err := BFS(g, vertexes[s], func(v Vertex[K, W]) error {
-			u := index[v.Key]
+		queue := []int{s}
+		for len(queue) > 0 {
+			u := queue[0]
+			queue = queue[1:]
 			for p := 0; p < len(vertexes); p++ {
 				if level[p] < 0 && flows[u][p] < capacity[u][p] {
 					level[p] = level[u] + 1
+					queue = append(queue, p)
 				}
 			}
-			return nil
-		})
-		return level[t] >= 0, err
+		}
+		return level[t] >= 0
 	}
 
-	for {
-		ok, err := buildLevel(from, to)
-		if err != nil {
-			return flow, err
-		}
-		if !ok {
-			break
-		}
+	for buildLevel(from, to) {
 		for {
 			start := make([]int, len(vertexes)+1)
 			f := send(vertexes, flows, capacity, level, from, to, getMaxValue(flow), start)
@@ -205,8 +208,13 @@ func mfDinic[K comparable, W number](g Graph[K, W], source, sink K) (W, error) {
 			flow += f
 		}
 	}
-
-	return flow, nil
+	var cut []K
+	for i, v := range vertexes {
+		if level[i] >= 0 {
+			cut = append(cut, v)
+		}
+	}
+	return flow, cut, nil
 }
 
 // Highest Label Preflow Push
@@ -0,0 +1,76 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func testFlowNetwork(n int, arcs [][3]int) Graph[int, int] {
+	g := NewGraph[int, int](true, "")
+	for i := 0; i < n; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, a := range arcs {
+		if err := g.AddEdge(Edge[int, int]{Key: i, Tail: a[0], Head: a[1], Weight: a[2]}); err != nil {
+			panic(err.Error())
+		}
+	}
+	return g
+}
+
+func TestMaxFlow(t *testing.T) {
+	cases := []struct {
+		n            int
+		arcs         [][3]int
+		source, sink int
+		flow         int
+	}{
+		// the source is not adjacent to the vertex 1.
+		{n: 3, arcs: [][3]int{{0, 2, 5}, {2, 1, 3}}, source: 0, sink: 1, flow: 3},
+		// the flow on 1->2 must be cancelled by the reverse residual arc 2->1.
+		{n: 4, arcs: [][3]int{{0, 1, 1}, {0, 2, 1}, {1, 2, 1}, {1, 3, 1}, {2, 3, 1}}, source: 0, sink: 3, flow: 2},
+		{n: 4, arcs: [][3]int{{0, 1, 1}, {1, 2, 1}, {0, 2, 1}, {2, 3, 2}, {1, 3, 1}}, source: 0, sink: 3, flow: 2},
+		// CLRS
+		{n: 6, arcs: [][3]int{{0, 1, 16}, {0, 2, 13}, {1, 3, 12}, {2, 1, 4}, {2, 4, 14}, {3, 2, 9}, {3, 5, 20}, {4, 3, 7}, {4, 5, 4}}, source: 0, sink: 5, flow: 23},
+		// the sink is not reachable.
+		{n: 3, arcs: [][3]int{{0, 1, 2}, {2, 1, 1}}, source: 0, sink: 2, flow: 0},
+	}
+	for i, c := range cases {
+		// the level graph used to be built on g instead of the residual graph,
+		// so the algorithm may never terminate.
+		done := make(chan int)
+		go func() {
+			f, err := MaxFlow(testFlowNetwork(c.n, c.arcs), c.source, c.sink)
+			if err != nil {
+				panic(err.Error())
+			}
+			done <- f
+		}()
+		select {
+		case f := <-done:
+			if f != c.flow {
+				panic(fmt.Sprintf("case %d: max flow should be %d,but get %d", i, c.flow, f))
+			}
+		case <-time.After(5 * time.Second):
+			panic(fmt.Sprintf("case %d: max flow does not terminate", i))
+		}
+	}
+	fmt.Println("=======> test max flow pass")
+}
@@ -49,6 +49,11 @@ const (
 	ProAvgDegree
 	ProMultiplicity
 	ProOrientation
+	ProBiconnected
+	ProDiameter
+	ProRadius
+	ProGirth
+	ProWienerIndex
 )
 
 // Graph [K, V, W] represents the graph object,
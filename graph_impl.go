@@ -58,6 +58,7 @@ type boolPropertySet[T bool] struct {
 	negWeight  property[T]
 	uniConnect property[T]
 	orient     property[T]
+	biconnect  property[T]
 }
 
 /*
@@ -77,6 +78,10 @@ type graph[K comparable, W number] struct {
 	maxDe property[int]
 	multi property[int]
 	avgDe property[float64]
+	diam  property[float64] // unweighted distances
+	rad   property[float64]
+	girth property[float64]
+	wien  property[float64]
 	vtx   map[K]*Vertex[K, W]
 	edges map[K]*Edge[K, W]
 	adj   *adjList[K, W]
@@ -316,6 +321,56 @@ func (g *graph[K, W]) Orientation() bool {
 	return g.prop.orient.value
 }
 
+func (g *graph[K, W]) isBiconnected() bool {
+	if g.prop.biconnect.version == g.ver {
+		return g.prop.biconnect.value
+	}
+	g.prop.biconnect.value = newIndexedGraph[K, W](g).isBiconnected()
+	g.prop.biconnect.version = g.ver
+	return g.prop.biconnect.value
+}
+
+// diameter and radius are computed together from the eccentricities.
+func (g *graph[K, W]) diameter() (float64, float64, error) {
+	if g.diam.version == g.ver {
+		return g.diam.value, g.rad.value, nil
+	}
+	_, ecc, err := eccentricity[K, W](g, false)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(ecc) == 0 {
+		return 0, 0, errEmptyGraph
+	}
+	g.diam.value, g.rad.value = eccentricityRange(ecc)
+	g.diam.version, g.rad.version = g.ver, g.ver
+	return g.diam.value, g.rad.value, nil
+}
+
+func (g *graph[K, W]) girthProperty() (float64, error) {
+	if g.girth.version == g.ver {
+		return g.girth.value, nil
+	}
+	v, err := Girth[K, W](g, false)
+	if err != nil {
+		return 0, err
+	}
+	g.girth.value, g.girth.version = v, g.ver
+	return v, nil
+}
+
+func (g *graph[K, W]) wienerIndex() (float64, error) {
+	if g.wien.version == g.ver {
+		return g.wien.value, nil
+	}
+	v, err := WienerIndex[K, W](g, false)
+	if err != nil {
+		return 0, err
+	}
+	g.wien.value, g.wien.version = v, g.ver
+	return v, nil
+}
+
 func (g *graph[K, W]) Property(p PropertyName) (GraphProperty[any], error) {
 	gp := GraphProperty[any]{Name: p}
 	switch p {
@@ -357,6 +412,29 @@ func (g *graph[K, W]) Property(p PropertyName) (GraphProperty[any], error) {
 		gp.Value = g.Multiplicity()
 	case ProOrientation:
 		gp.Value = g.Orientation()
+	case ProBiconnected:
+		gp.Value = g.isBiconnected()
+	case ProDiameter, ProRadius:
+		d, r, err := g.diameter()
+		if err != nil {
+			return gp, err
+		}
+		gp.Value = d
+		if p == ProRadius {
+			gp.Value = r
+		}
+	case ProGirth:
+		v, err := g.girthProperty()
+		if err != nil {
+			return gp, err
+		}
+		gp.Value = v
+	case ProWienerIndex:
+		v, err := g.wienerIndex()
+		if err != nil {
+			return gp, err
+		}
+		gp.Value = v
 	default:
 		return gp, errUnknownProperty
 	}
@@ -834,6 +912,7 @@ func (g *graph[K, W]) SetEdgeWeight(key K, weight W) error {
 		return errEdgeNotExists
 	}
 	e.Weight = weight
+	g.ver++
 	return nil
 	// TODO: update weight on adjlist
 }
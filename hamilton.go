@@ -0,0 +1,232 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// graphs with at most hamiltonDPLimit vertexes are solved by dynamic programming over subsets.
+const hamiltonDPLimit = 20
+
+// hamiltonGraph is the underlying simple graph (loops ignored, direction kept),
+// edge[u][v] is the index of the lightest edge from u to v.
+type hamiltonGraph struct {
+	n    int
+	adj  [][]int
+	mask []uint32
+	edge []map[int]int
+}
+
+func newHamiltonGraph[K comparable, W number](ig *indexedGraph[K, W]) *hamiltonGraph {
+	n := ig.order()
+	h := &hamiltonGraph{
+		n:    n,
+		adj:  make([][]int, n),
+		mask: make([]uint32, n),
+		edge: make([]map[int]int, n),
+	}
+	for u := 0; u < n; u++ {
+		h.edge[u] = make(map[int]int)
+		for _, a := range ig.out[u] {
+			if a.to == u {
+				continue
+			}
+			if i, ok := h.edge[u][a.to]; ok {
+				if ig.edges[i].Weight > ig.edges[a.edge].Weight {
+					h.edge[u][a.to] = a.edge
+				}
+				continue
+			}
+			h.edge[u][a.to] = a.edge
+			h.adj[u] = append(h.adj[u], a.to)
+			if a.to < 32 {
+				h.mask[u] |= 1 << a.to
+			}
+		}
+	}
+	return h
+}
+
+func (h *hamiltonGraph) has(u, v int) bool {
+	_, ok := h.edge[u][v]
+	return ok
+}
+
+/*
+Dynamic programming over subsets in O(2^n*n^2) time,
+ends[S] is the set of vertexes v such that there is a path which visits exactly the vertexes of S and ends at v.
+
+For a cycle all the paths start from vertex 0, for a path every vertex can be the start.
+*/
+func (h *hamiltonGraph) dp(cycle bool) []int {
+	n := h.n
+	full := uint32(1)<<n - 1
+	ends := make([]uint32, full+1)
+	if cycle {
+		ends[1] = 1
+	} else {
+		for v := 0; v < n; v++ {
+			ends[1<<v] = 1 << v
+		}
+	}
+	for s := uint32(1); s < full; s++ {
+		for e := ends[s]; e != 0; e &= e - 1 {
+			v := bits.TrailingZeros32(e)
+			for nx := h.mask[v] &^ s; nx != 0; nx &= nx - 1 {
+				u := bits.TrailingZeros32(nx)
+				ends[s|1<<u] |= 1 << u
+			}
+		}
+	}
+	last := -1
+	for e := ends[full]; e != 0; e &= e - 1 {
+		v := bits.TrailingZeros32(e)
+		if !cycle || h.has(v, 0) {
+			last = v
+			break
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+	// walk backwards, the previous vertex must be an end of the remaining set and adjacent to the current one.
+	res := make([]int, n)
+	s, v := full, last
+	for i := n - 1; i > 0; i-- {
+		res[i] = v
+		s &^= 1 << v
+		for e := ends[s]; e != 0; e &= e - 1 {
+			u := bits.TrailingZeros32(e)
+			if h.has(u, v) {
+				v = u
+				break
+			}
+		}
+	}
+	res[0] = v
+	return res
+}
+
+// Backtracking search, neighbours with fewer choices left are tried first (Warnsdorff's rule).
+func (h *hamiltonGraph) backtrack(cycle bool) []int {
+	n := h.n
+	visited := make([]bool, n)
+	res := make([]int, 0, n)
+	var dfs func(v int) bool
+	dfs = func(v int) bool {
+		visited[v] = true
+		res = append(res, v)
+		if len(res) == n {
+			if !cycle || h.has(v, res[0]) {
+				return true
+			}
+		} else {
+			free := func(u int) int {
+				c := 0
+				for _, w := range h.adj[u] {
+					if !visited[w] {
+						c++
+					}
+				}
+				return c
+			}
+			var next []int
+			for _, u := range h.adj[v] {
+				if !visited[u] {
+					next = append(next, u)
+				}
+			}
+			sort.SliceStable(next, func(i, j int) bool { return free(next[i]) < free(next[j]) })
+			for _, u := range next {
+				if dfs(u) {
+					return true
+				}
+			}
+		}
+		visited[v] = false
+		res = res[:len(res)-1]
+		return false
+	}
+	if cycle {
+		if dfs(0) {
+			return res
+		}
+		return nil
+	}
+	for s := 0; s < n; s++ {
+		if dfs(s) {
+			return res
+		}
+	}
+	return nil
+}
+
+func hamiltonian[K comparable, W number](g Graph[K, W], cycle bool) (Path[K, W], error) {
+	notExists := errNoHamiltonPath
+	if cycle {
+		notExists = errNoHamiltonCycle
+	}
+	if g == nil {
+		return Path[K, W]{}, errNilGraph
+	}
+	if g.Order() == 0 {
+		return Path[K, W]{}, errEmptyGraph
+	}
+	ig := newIndexedGraph(g)
+	h := newHamiltonGraph(ig)
+	if cycle && (h.n == 1 || (h.n == 2 && !ig.digraph)) {
+		return Path[K, W]{}, notExists
+	}
+	var vs []int
+	if h.n <= hamiltonDPLimit {
+		vs = h.dp(cycle)
+	} else {
+		vs = h.backtrack(cycle)
+	}
+	if vs == nil {
+		return Path[K, W]{}, notExists
+	}
+	if cycle {
+		vs = append(vs, vs[0])
+	}
+	p := Path[K, W]{
+		Source: ig.keys[vs[0]],
+		Target: ig.keys[vs[len(vs)-1]],
+		Edges:  make([]K, 0, len(vs)-1),
+	}
+	for i := 1; i < len(vs); i++ {
+		e := ig.edges[h.edge[vs[i-1]][vs[i]]]
+		p.Edges = append(p.Edges, e.Key)
+		p.Weight += e.Weight
+	}
+	return p, nil
+}
+
+// Calculate a hamiltonian cycle of the graph, i.e. a cycle which visits every vertex exactly once,
+// the edges of the returned path are in the order of the cycle and Source equals Target.
+// Graphs with few vertexes are solved by dynamic programming over subsets, otherwise by backtracking
+// (which may take exponential time). If there is no hamiltonian cycle an error will be returned.
+func HamiltonianCycle[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	return hamiltonian(g, true)
+}
+
+// Calculate a hamiltonian path of the graph, i.e. a path which visits every vertex exactly once.
+func HamiltonianPath[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	return hamiltonian(g, false)
+}
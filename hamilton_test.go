@@ -0,0 +1,100 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testPetersen() Graph[int, int] {
+	var es [][3]int
+	for i := 0; i < 5; i++ {
+		es = append(es, [3]int{i, (i + 1) % 5, 1}, [3]int{i, i + 5, 1}, [3]int{i + 5, (i+2)%5 + 5, 1})
+	}
+	return testMultigraph(false, 10, es)
+}
+
+// checkHamiltonian verifies that p visits every vertex of g exactly once.
+func checkHamiltonian(g Graph[int, int], p Path[int, int], cycle bool) {
+	checkTrail(g, p)
+	visited := map[int]bool{p.Source: true}
+	cur := p.Source
+	for _, k := range p.Edges {
+		e, _ := g.GetEdgeByKey(k)
+		if e.Tail == cur {
+			cur = e.Head
+		} else {
+			cur = e.Tail
+		}
+		if visited[cur] && !(cycle && cur == p.Source && len(visited) == g.Order()) {
+			panic(fmt.Sprintf("vertex %d is visited twice in %+v", cur, p))
+		}
+		visited[cur] = true
+	}
+	if len(visited) != g.Order() || (cycle && p.Source != p.Target) {
+		panic(fmt.Sprintf("wrong hamiltonian path %+v", p))
+	}
+}
+
+func TestHamiltonian(t *testing.T) {
+	g := testPetersen()
+	if _, err := HamiltonianCycle(g); err != errNoHamiltonCycle {
+		panic(fmt.Sprintf("petersen graph is not hamiltonian, %v", err))
+	}
+	p, err := HamiltonianPath(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	checkHamiltonian(g, p, false)
+
+	ig := newIndexedGraph(g)
+	h := newHamiltonGraph(ig)
+	if h.backtrack(true) != nil || h.backtrack(false) == nil {
+		panic("wrong backtracking result on petersen graph")
+	}
+	// the prism graph is hamiltonian.
+	g = testMultigraph(false, 6, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 0, 1}, {3, 4, 1}, {4, 5, 1}, {5, 3, 1}, {0, 3, 1}, {1, 4, 1}, {2, 5, 1}})
+	if p, err = HamiltonianCycle(g); err != nil {
+		panic(err.Error())
+	}
+	checkHamiltonian(g, p, true)
+	h = newHamiltonGraph(newIndexedGraph(g))
+	if vs := h.backtrack(true); len(vs) != 6 {
+		panic(fmt.Sprintf("wrong backtracking result %v", vs))
+	}
+
+	d := testMultigraph(true, 4, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 0, 1}, {0, 2, 1}})
+	if p, err = HamiltonianCycle(d); err != nil {
+		panic(err.Error())
+	}
+	checkHamiltonian(d, p, true)
+	if err = d.RemoveEdgeByKey(3); err != nil {
+		panic(err.Error())
+	}
+	if _, err = HamiltonianCycle(d); err != errNoHamiltonCycle {
+		panic(fmt.Sprintf("digraph is not hamiltonian, %v", err))
+	}
+	if p, err = HamiltonianPath(d); err != nil {
+		panic(err.Error())
+	}
+	checkHamiltonian(d, p, false)
+	if p.Source != 0 || p.Target != 3 {
+		panic(fmt.Sprintf("wrong hamiltonian path %+v", p))
+	}
+	fmt.Println("=======> test hamiltonian pass")
+}
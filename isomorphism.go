@@ -0,0 +1,420 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+// IsomorphismOptions controls how the vertices and edges of a pattern graph
+// are allowed to be mapped onto a host graph.
+type IsomorphismOptions[K comparable, W number] struct {
+	// If Induced is true, two host vertices that are images of pattern vertices
+	// must not be adjacent unless the pattern vertices are adjacent too
+	// (in the same direction, for digraphs).
+	Induced bool
+	// VertexMatch reports whether the host vertex can be the image of the pattern vertex.
+	// If it is nil, any vertex matches.
+	VertexMatch func(host, pattern Vertex[K, W]) bool
+	// EdgeMatch reports whether the host edge can be the image of the pattern edge.
+	// If it is nil, any edge matches.
+	EdgeMatch func(host, pattern Edge[K, W]) bool
+}
+
+// Embedding represents an occurrence of a pattern graph in a host graph.
+// Vertexes maps each pattern vertex to a host vertex,
+// and Edges maps each pattern edge to a host edge.
+type Embedding[K comparable] struct {
+	Vertexes map[K]K
+	Edges    map[K]K
+}
+
+// Match vertex labels, every label of the pattern vertex must exist on the host vertex with the same value.
+func MatchVertexLabels[K comparable, W number](host, pattern Vertex[K, W]) bool {
+	return containsLabels(host.Labels, pattern.Labels)
+}
+
+// Match edge labels, every label of the pattern edge must exist on the host edge with the same value.
+func MatchEdgeLabels[K comparable, W number](host, pattern Edge[K, W]) bool {
+	return containsLabels(host.Labels, pattern.Labels)
+}
+
+func containsLabels(labels, sub map[string]string) bool {
+	for k, v := range sub {
+		l, ok := labels[k]
+		if !ok || l != v {
+			return false
+		}
+	}
+	return true
+}
+
+// isoIndex is a static snapshot of a graph used by the matcher,
+// out[u][v] records all edges u->v (for an undirected graph out and in are the same map).
+type isoIndex[K comparable, W number] struct {
+	digraph bool
+	vtx     map[K]Vertex[K, W]
+	keys    []K
+	out     map[K]map[K][]Edge[K, W]
+	in      map[K]map[K][]Edge[K, W]
+	outDe   map[K]int
+	inDe    map[K]int
+}
+
+func newIsoIndex[K comparable, W number](g Graph[K, W]) *isoIndex[K, W] {
+	vs := g.AllVertexes()
+	idx := &isoIndex[K, W]{
+		digraph: g.IsDigraph(),
+		vtx:     make(map[K]Vertex[K, W]),
+		keys:    make([]K, len(vs)),
+		out:     make(map[K]map[K][]Edge[K, W]),
+		outDe:   make(map[K]int),
+	}
+	for i, v := range vs {
+		idx.vtx[v.Key] = v
+		idx.keys[i] = v.Key
+		idx.out[v.Key] = make(map[K][]Edge[K, W])
+	}
+	if idx.digraph {
+		idx.in = make(map[K]map[K][]Edge[K, W])
+		idx.inDe = make(map[K]int)
+		for _, v := range vs {
+			idx.in[v.Key] = make(map[K][]Edge[K, W])
+		}
+	} else {
+		idx.in = idx.out
+		idx.inDe = idx.outDe
+	}
+	for _, e := range g.AllEdges() {
+		// the direction of an arc is tail -> head.
+		idx.out[e.Tail][e.Head] = append(idx.out[e.Tail][e.Head], e)
+		idx.outDe[e.Tail]++
+		if idx.digraph {
+			idx.in[e.Head][e.Tail] = append(idx.in[e.Head][e.Tail], e)
+			idx.inDe[e.Head]++
+		} else if e.Head != e.Tail {
+			idx.out[e.Head][e.Tail] = append(idx.out[e.Head][e.Tail], e)
+			idx.outDe[e.Head]++
+		}
+	}
+	return idx
+}
+
+type isoMatcher[K comparable, W number] struct {
+	host    *isoIndex[K, W]
+	pattern *isoIndex[K, W]
+	opts    IsomorphismOptions[K, W]
+	order   []K     // the order in which pattern vertexes are mapped
+	parent  []int   // parent[i] is the position of a mapped neighbour of order[i] in order, or -1
+	core    map[K]K // pattern vertex -> host vertex
+	rev     map[K]K // host vertex -> pattern vertex
+	edges   map[K]K // pattern edge -> host edge
+	visitor func(Embedding[K]) error
+}
+
+func newIsoMatcher[K comparable, W number](host, pattern Graph[K, W], opts *IsomorphismOptions[K, W]) *isoMatcher[K, W] {
+	m := &isoMatcher[K, W]{
+		host:    newIsoIndex(host),
+		pattern: newIsoIndex(pattern),
+		core:    make(map[K]K),
+		rev:     make(map[K]K),
+		edges:   make(map[K]K),
+	}
+	if opts != nil {
+		m.opts = *opts
+	}
+	m.sortPattern()
+	return m
+}
+
+// Sort the pattern vertexes so that each vertex (except the first vertex of every component)
+// has at least one neighbour before it, vertexes with more mapped neighbours and higher degree go first.
+func (m *isoMatcher[K, W]) sortPattern() {
+	p := m.pattern
+	n := len(p.keys)
+	m.order = make([]K, 0, n)
+	m.parent = make([]int, 0, n)
+	pos := make(map[K]int)
+	conn := make(map[K]int)
+	degree := func(v K) int {
+		if p.digraph {
+			return p.outDe[v] + p.inDe[v]
+		}
+		return p.outDe[v]
+	}
+	for len(m.order) < n {
+		var next K
+		best, bestDe := -1, -1
+		for _, v := range p.keys {
+			if _, ok := pos[v]; ok {
+				continue
+			}
+			if c, d := conn[v], degree(v); c > best || (c == best && d > bestDe) {
+				next, best, bestDe = v, c, d
+			}
+		}
+		par := -1
+		for u := range p.out[next] {
+			if i, ok := pos[u]; ok && (par == -1 || i < par) {
+				par = i
+			}
+		}
+		for u := range p.in[next] {
+			if i, ok := pos[u]; ok && (par == -1 || i < par) {
+				par = i
+			}
+		}
+		pos[next] = len(m.order)
+		m.order = append(m.order, next)
+		m.parent = append(m.parent, par)
+		for u := range p.out[next] {
+			conn[u]++
+		}
+		if p.digraph {
+			for u := range p.in[next] {
+				conn[u]++
+			}
+		}
+	}
+}
+
+func (m *isoMatcher[K, W]) run(depth int) error {
+	if depth == len(m.order) {
+		em := Embedding[K]{
+			Vertexes: make(map[K]K, len(m.core)),
+			Edges:    make(map[K]K, len(m.edges)),
+		}
+		for k, v := range m.core {
+			em.Vertexes[k] = v
+		}
+		for k, v := range m.edges {
+			em.Edges[k] = v
+		}
+		return m.visitor(em)
+	}
+	p := m.order[depth]
+	for _, h := range m.candidates(depth) {
+		if _, ok := m.rev[h]; ok {
+			continue
+		}
+		assigned, ok := m.feasible(p, h)
+		if !ok {
+			continue
+		}
+		m.core[p] = h
+		m.rev[h] = p
+		for pe, he := range assigned {
+			m.edges[pe] = he
+		}
+		if err := m.run(depth + 1); err != nil {
+			return err
+		}
+		for pe := range assigned {
+			delete(m.edges, pe)
+		}
+		delete(m.core, p)
+		delete(m.rev, h)
+	}
+	return nil
+}
+
+// Candidate host vertexes of order[depth]: the neighbours of the image of its parent,
+// or all host vertexes if it has no mapped neighbour.
+func (m *isoMatcher[K, W]) candidates(depth int) []K {
+	par := m.parent[depth]
+	if par < 0 {
+		return m.host.keys
+	}
+	p, q := m.order[depth], m.order[par]
+	hq := m.core[q]
+	var cs []K
+	if _, ok := m.pattern.out[q][p]; ok {
+		for h := range m.host.out[hq] {
+			cs = append(cs, h)
+		}
+	} else {
+		for h := range m.host.in[hq] {
+			cs = append(cs, h)
+		}
+	}
+	return cs
+}
+
+// Check whether pattern vertex p can be mapped to host vertex h under current partial mapping,
+// if so, return the assignment of the pattern edges between p and the mapped vertexes.
+func (m *isoMatcher[K, W]) feasible(p, h K) (map[K]K, bool) {
+	host, pat := m.host, m.pattern
+	if host.outDe[h] < pat.outDe[p] || host.inDe[h] < pat.inDe[p] {
+		return nil, false
+	}
+	if m.opts.VertexMatch != nil && !m.opts.VertexMatch(host.vtx[h], pat.vtx[p]) {
+		return nil, false
+	}
+	assigned := make(map[K]K)
+	check := func(pes, hes []Edge[K, W]) bool {
+		if len(pes) == 0 {
+			return !m.opts.Induced || len(hes) == 0
+		}
+		return m.assignEdges(pes, hes, assigned)
+	}
+	// loops
+	if !check(pat.out[p][p], host.out[h][h]) {
+		return nil, false
+	}
+	// edges between p and mapped pattern vertexes.
+	for q, pes := range pat.out[p] {
+		if hq, ok := m.core[q]; ok && q != p {
+			if !check(pes, host.out[h][hq]) {
+				return nil, false
+			}
+		}
+	}
+	if pat.digraph {
+		for q, pes := range pat.in[p] {
+			if hq, ok := m.core[q]; ok && q != p {
+				if !check(pes, host.in[h][hq]) {
+					return nil, false
+				}
+			}
+		}
+	}
+	if m.opts.Induced {
+		// host edges between h and mapped host vertexes must have preimages.
+		for hq := range host.out[h] {
+			if q, ok := m.rev[hq]; ok && len(pat.out[p][q]) == 0 {
+				return nil, false
+			}
+		}
+		if host.digraph {
+			for hq := range host.in[h] {
+				if q, ok := m.rev[hq]; ok && len(pat.in[p][q]) == 0 {
+					return nil, false
+				}
+			}
+		}
+	}
+	return assigned, true
+}
+
+// Assign every pattern edge a distinct host edge (the edge sets are small, so augmenting paths are enough),
+// for a simple graph this degenerates into a single comparison.
+func (m *isoMatcher[K, W]) assignEdges(pes, hes []Edge[K, W], assigned map[K]K) bool {
+	if len(pes) > len(hes) || (m.opts.Induced && len(pes) != len(hes)) {
+		return false
+	}
+	match := make([]int, len(hes))
+	for i := range match {
+		match[i] = -1
+	}
+	var augment func(int, []bool) bool
+	augment = func(i int, seen []bool) bool {
+		for j, he := range hes {
+			if seen[j] {
+				continue
+			}
+			if m.opts.EdgeMatch != nil && !m.opts.EdgeMatch(he, pes[i]) {
+				continue
+			}
+			seen[j] = true
+			if match[j] == -1 || augment(match[j], seen) {
+				match[j] = i
+				return true
+			}
+		}
+		return false
+	}
+	for i := range pes {
+		if !augment(i, make([]bool, len(hes))) {
+			return false
+		}
+	}
+	for j, i := range match {
+		if i != -1 {
+			assigned[pes[i].Key] = hes[j].Key
+		}
+	}
+	return true
+}
+
+// Find all occurrences of the pattern graph in the host graph, and call visitor for each embedding found.
+// By default non-induced embeddings are enumerated, i.e. the host may contain extra edges between
+// the images of pattern vertexes, set opts.Induced to enumerate induced embeddings only.
+// Vertex and edge predicates (for example labels or weights) can be provided via opts,
+// and the direction of arcs is respected if the graphs are digraphs.
+// If visitor returns an error the search stops and the error is returned.
+// Note that automorphisms of the pattern produce distinct embeddings with the same image.
+func SubgraphIsomorphisms[K comparable, W number](host, pattern Graph[K, W], opts *IsomorphismOptions[K, W], visitor func(Embedding[K]) error) error {
+	if host == nil || pattern == nil {
+		return errNilGraph
+	}
+	if host.IsDigraph() != pattern.IsDigraph() {
+		return errNotSameType
+	}
+	if pattern.Order() == 0 || pattern.Order() > host.Order() {
+		return nil
+	}
+	m := newIsoMatcher(host, pattern, opts)
+	m.visitor = visitor
+	return m.run(0)
+}
+
+// Find one occurrence of the pattern graph in the host graph,
+// return false if the host does not contain the pattern.
+func SubgraphIsomorphism[K comparable, W number](host, pattern Graph[K, W], opts *IsomorphismOptions[K, W]) (Embedding[K], bool, error) {
+	var res Embedding[K]
+	var found bool
+	err := SubgraphIsomorphisms(host, pattern, opts, func(em Embedding[K]) error {
+		res, found = em, true
+		return errNone
+	})
+	if err != nil && err != errNone {
+		return res, false, err
+	}
+	return res, found, nil
+}
+
+// Count the occurrences of the pattern graph in the host graph.
+func CountSubgraphIsomorphisms[K comparable, W number](host, pattern Graph[K, W], opts *IsomorphismOptions[K, W]) (int, error) {
+	var n int
+	err := SubgraphIsomorphisms(host, pattern, opts, func(Embedding[K]) error {
+		n++
+		return nil
+	})
+	return n, err
+}
+
+// Determine whether g1 and g2 are isomorphic, if so, return an isomorphism which maps vertexes of g2 to g1.
+// Vertex and edge predicates in opts are respected, and the Induced option is ignored.
+func Isomorphism[K comparable, W number](g1, g2 Graph[K, W], opts *IsomorphismOptions[K, W]) (Embedding[K], bool, error) {
+	if g1 == nil || g2 == nil {
+		return Embedding[K]{}, false, errNilGraph
+	}
+	if g1.Order() != g2.Order() || g1.Size() != g2.Size() {
+		return Embedding[K]{}, false, nil
+	}
+	o := IsomorphismOptions[K, W]{Induced: true}
+	if opts != nil {
+		o.VertexMatch = opts.VertexMatch
+		o.EdgeMatch = opts.EdgeMatch
+	}
+	if g1.Order() == 0 {
+		return Embedding[K]{Vertexes: map[K]K{}, Edges: map[K]K{}}, g1.IsDigraph() == g2.IsDigraph(), nil
+	}
+	return SubgraphIsomorphism(g1, g2, &o)
+}
+
+// Determine whether g1 and g2 are isomorphic.
+func IsIsomorphic[K comparable, W number](g1, g2 Graph[K, W]) (bool, error) {
+	_, ok, err := Isomorphism(g1, g2, nil)
+	return ok, err
+}
@@ -0,0 +1,138 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSubgraphIsomorphism(t *testing.T) {
+	// triangles in K4: 4 triangles, each has 6 automorphisms.
+	n, err := CountSubgraphIsomorphisms(CompleteGraph(4), Cycle(3), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if n != 24 {
+		panic(fmt.Sprintf("K4 should contains 24 triangle embeddings,but get %d", n))
+	}
+	// C4 is not an induced subgraph of K4.
+	n, err = CountSubgraphIsomorphisms(CompleteGraph(4), Cycle(4), &IsomorphismOptions[int, int]{Induced: true})
+	if err != nil {
+		panic(err.Error())
+	}
+	if n != 0 {
+		panic(fmt.Sprintf("K4 should not contains induced C4,but get %d", n))
+	}
+	// petersen graph has girth 5.
+	_, ok, err := SubgraphIsomorphism(PetersenGraph(), Cycle(4), nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if ok {
+		panic("petersen graph should not contains C4")
+	}
+	fmt.Println("subgraph isomorphism pass")
+}
+
+func TestSubgraphIsomorphismDigraph(t *testing.T) {
+	host := NewDigraph[string, int]("services")
+	for _, v := range []Vertex[string, int]{
+		{Key: "a", Labels: map[string]string{"kind": "service", "tier": "prod"}},
+		{Key: "q", Labels: map[string]string{"kind": "queue"}},
+		{Key: "b", Labels: map[string]string{"kind": "service", "tier": "prod"}},
+		{Key: "c", Labels: map[string]string{"kind": "service", "tier": "dev"}},
+	} {
+		if err := host.AddVertex(v); err != nil {
+			panic(err.Error())
+		}
+	}
+	for _, e := range []Edge[string, int]{
+		{Key: "aq", Tail: "a", Head: "q"},
+		{Key: "qb", Tail: "q", Head: "b"},
+		{Key: "qc", Tail: "q", Head: "c"},
+	} {
+		if err := host.AddEdge(e); err != nil {
+			panic(err.Error())
+		}
+	}
+	pattern := NewDigraph[string, int]("motif")
+	for _, v := range []Vertex[string, int]{
+		{Key: "x", Labels: map[string]string{"kind": "service", "tier": "prod"}},
+		{Key: "y", Labels: map[string]string{"kind": "queue"}},
+		{Key: "z", Labels: map[string]string{"kind": "service", "tier": "prod"}},
+	} {
+		if err := pattern.AddVertex(v); err != nil {
+			panic(err.Error())
+		}
+	}
+	for _, e := range []Edge[string, int]{
+		{Key: "xy", Tail: "x", Head: "y"},
+		{Key: "yz", Tail: "y", Head: "z"},
+	} {
+		if err := pattern.AddEdge(e); err != nil {
+			panic(err.Error())
+		}
+	}
+	opts := &IsomorphismOptions[string, int]{VertexMatch: MatchVertexLabels[string, int]}
+	var res []Embedding[string]
+	err := SubgraphIsomorphisms[string, int](host, pattern, opts, func(em Embedding[string]) error {
+		res = append(res, em)
+		return nil
+	})
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(res) != 1 {
+		panic(fmt.Sprintf("expect 1 embedding,but get %d", len(res)))
+	}
+	em := res[0]
+	if em.Vertexes["x"] != "a" || em.Vertexes["y"] != "q" || em.Vertexes["z"] != "b" {
+		panic(fmt.Sprintf("wrong embedding %v", em.Vertexes))
+	}
+	if em.Edges["xy"] != "aq" || em.Edges["yz"] != "qb" {
+		panic(fmt.Sprintf("wrong edge embedding %v", em.Edges))
+	}
+}
+
+func TestIsomorphic(t *testing.T) {
+	g := NewGraph[int, int](false, "")
+	for i := 0; i < 10; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	// relabel petersen graph: i -> 3i mod 10.
+	for _, e := range PetersenGraph().AllEdges() {
+		e.Head, e.Tail = (3*e.Head)%10, (3*e.Tail)%10
+		if err := g.AddEdge(e); err != nil {
+			panic(err.Error())
+		}
+	}
+	ok, err := IsIsomorphic(PetersenGraph(), g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !ok {
+		panic("relabeled petersen graph should be isomorphic")
+	}
+	ok, err = IsIsomorphic(Cycle(6), CompleteBipartite(3, 2))
+	if err != nil {
+		panic(err.Error())
+	}
+	if ok {
+		panic("C6 is not isomorphic to K3,2")
+	}
+}
@@ -0,0 +1,262 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+// simpleNeighbours returns the neighbours of every vertex in the underlying simple undirected graph,
+// i.e. the direction of arcs, loops and multiple edges are ignored.
+func (g *indexedGraph[K, W]) simpleNeighbours() [][]int {
+	n := g.order()
+	res := make([][]int, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for v := 0; v < n; v++ {
+		mark[v] = v
+		add := func(as []arc) {
+			for _, a := range as {
+				if mark[a.to] != v {
+					mark[a.to] = v
+					res[v] = append(res[v], a.to)
+				}
+			}
+		}
+		add(g.out[v])
+		if g.digraph {
+			add(g.in[v])
+		}
+	}
+	return res
+}
+
+// Batagelj-Zaversnik algorithm, compute core numbers in O(m) time.
+// Vertexes are kept in an array sorted by current degree (bin sort),
+// every time the vertex with minimum degree is removed and the degrees of its neighbours with larger degree are decreased.
+func coreNumbers(adj [][]int) []int {
+	n := len(adj)
+	deg := make([]int, n)
+	md := 0
+	for v := range adj {
+		deg[v] = len(adj[v])
+		md = max(md, deg[v])
+	}
+	// bin[d] is the start position of vertexes with degree d in vert.
+	bin := make([]int, md+1)
+	for _, d := range deg {
+		bin[d]++
+	}
+	start := 0
+	for d := 0; d <= md; d++ {
+		num := bin[d]
+		bin[d] = start
+		start += num
+	}
+	pos := make([]int, n)
+	vert := make([]int, n)
+	for v := 0; v < n; v++ {
+		pos[v] = bin[deg[v]]
+		vert[pos[v]] = v
+		bin[deg[v]]++
+	}
+	for d := md; d > 0; d-- {
+		bin[d] = bin[d-1]
+	}
+	if md >= 0 && len(bin) > 0 {
+		bin[0] = 0
+	}
+	for i := 0; i < n; i++ {
+		v := vert[i]
+		for _, u := range adj[v] {
+			if deg[u] > deg[v] {
+				du, pu := deg[u], pos[u]
+				pw := bin[du]
+				w := vert[pw]
+				if u != w {
+					pos[u], pos[w] = pw, pu
+					vert[pu], vert[pw] = w, u
+				}
+				bin[du]++
+				deg[u]--
+			}
+		}
+	}
+	return deg
+}
+
+// Calculate the core number of every vertex, the core number of v is the largest k
+// such that v belongs to the k-core (the maximal subgraph in which every vertex has degree at least k).
+// The direction of arcs, loops and multiple edges are ignored.
+func CoreNumbers[K comparable, W number](g Graph[K, W]) (map[K]int, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	core := coreNumbers(ig.simpleNeighbours())
+	res := make(map[K]int)
+	for i, k := range ig.keys {
+		res[k] = core[i]
+	}
+	return res, nil
+}
+
+// Calculate the k-core of graph g, i.e. the maximal induced subgraph in which every vertex has degree at least k
+// (in the underlying simple undirected graph).
+func KCore[K comparable, W number](g Graph[K, W], k int) (Graph[K, W], error) {
+	core, err := CoreNumbers(g)
+	if err != nil {
+		return nil, err
+	}
+	var vs []K
+	for v, c := range core {
+		if c < k {
+			vs = append(vs, v)
+		}
+	}
+	return InducedSubgraph(g, vs)
+}
+
+// Calculate the k-shell of graph g, i.e. the subgraph induced by vertexes with core number exactly k.
+func KShell[K comparable, W number](g Graph[K, W], k int) (Graph[K, W], error) {
+	core, err := CoreNumbers(g)
+	if err != nil {
+		return nil, err
+	}
+	var vs []K
+	for v, c := range core {
+		if c != k {
+			vs = append(vs, v)
+		}
+	}
+	return InducedSubgraph(g, vs)
+}
+
+// Count the triangles through every vertex in O(m^1.5) time:
+// orient every edge from the vertex with lower rank (degree, index) to the vertex with higher rank,
+// then every triangle is found exactly once as u->v, u->w, v->w.
+func triangles(adj [][]int) []int {
+	n := len(adj)
+	rank := func(u, v int) bool {
+		if len(adj[u]) != len(adj[v]) {
+			return len(adj[u]) < len(adj[v])
+		}
+		return u < v
+	}
+	fwd := make([][]int, n)
+	for u := 0; u < n; u++ {
+		for _, v := range adj[u] {
+			if rank(u, v) {
+				fwd[u] = append(fwd[u], v)
+			}
+		}
+	}
+	res := make([]int, n)
+	mark := make([]int, n)
+	for i := range mark {
+		mark[i] = -1
+	}
+	for u := 0; u < n; u++ {
+		for _, v := range fwd[u] {
+			mark[v] = u
+		}
+		for _, v := range fwd[u] {
+			for _, w := range fwd[v] {
+				if mark[w] == u {
+					res[u]++
+					res[v]++
+					res[w]++
+				}
+			}
+		}
+	}
+	return res
+}
+
+// Count the number of triangles through every vertex.
+// The direction of arcs, loops and multiple edges are ignored.
+func Triangles[K comparable, W number](g Graph[K, W]) (map[K]int, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	ts := triangles(ig.simpleNeighbours())
+	res := make(map[K]int)
+	for i, k := range ig.keys {
+		res[k] = ts[i]
+	}
+	return res, nil
+}
+
+// Calculate the local clustering coefficient of every vertex, c(v) = 2T(v)/(d(v)(d(v)-1)),
+// where T(v) is the number of triangles through v. The coefficient of vertexes with degree less than 2 is 0.
+// The direction of arcs, loops and multiple edges are ignored.
+func LocalClusteringCoefficients[K comparable, W number](g Graph[K, W]) (map[K]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	adj := ig.simpleNeighbours()
+	ts := triangles(adj)
+	res := make(map[K]float64)
+	for i, k := range ig.keys {
+		d := float64(len(adj[i]))
+		if d < 2 {
+			res[k] = 0
+			continue
+		}
+		res[k] = 2 * float64(ts[i]) / (d * (d - 1))
+	}
+	return res, nil
+}
+
+// Calculate the average of local clustering coefficients of all vertexes.
+func AverageClusteringCoefficient[K comparable, W number](g Graph[K, W]) (float64, error) {
+	cs, err := LocalClusteringCoefficients(g)
+	if err != nil {
+		return 0, err
+	}
+	if len(cs) == 0 {
+		return 0, nil
+	}
+	var sum float64
+	for _, c := range cs {
+		sum += c
+	}
+	return sum / float64(len(cs)), nil
+}
+
+// Calculate the global clustering coefficient (transitivity) of graph g,
+// which is 3 * number of triangles / number of connected triples.
+// The direction of arcs, loops and multiple edges are ignored.
+func GlobalClusteringCoefficient[K comparable, W number](g Graph[K, W]) (float64, error) {
+	if g == nil {
+		return 0, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	adj := ig.simpleNeighbours()
+	ts := triangles(adj)
+	var t, triples float64
+	for i := range adj {
+		d := float64(len(adj[i]))
+		t += float64(ts[i])
+		triples += d * (d - 1) / 2
+	}
+	if triples == 0 {
+		return 0, nil
+	}
+	// every triangle is counted 3 times in t.
+	return t / triples, nil
+}
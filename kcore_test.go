@@ -0,0 +1,110 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCoreNumbers(t *testing.T) {
+	// K4 with a pendant path 3-4-5.
+	g := CompleteGraph(4)
+	_ = g.AddVertex(Vertex[int, int]{Key: 4})
+	_ = g.AddVertex(Vertex[int, int]{Key: 5})
+	_ = g.AddEdge(Edge[int, int]{Key: 100, Head: 3, Tail: 4})
+	_ = g.AddEdge(Edge[int, int]{Key: 101, Head: 4, Tail: 5})
+	core, err := CoreNumbers(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	expect := []int{3, 3, 3, 3, 1, 1}
+	for i, c := range expect {
+		if core[i] != c {
+			panic(fmt.Sprintf("core number of %d should be %d,but get %d", i, c, core[i]))
+		}
+	}
+	kc, err := KCore(g, 3)
+	if err != nil {
+		panic(err.Error())
+	}
+	if kc.Order() != 4 || kc.Size() != 6 {
+		panic(fmt.Sprintf("3-core should be K4,but get order=%d size=%d", kc.Order(), kc.Size()))
+	}
+	ks, err := KShell(g, 1)
+	if err != nil {
+		panic(err.Error())
+	}
+	if ks.Order() != 2 || ks.Size() != 1 {
+		panic(fmt.Sprintf("wrong 1-shell order=%d size=%d", ks.Order(), ks.Size()))
+	}
+}
+
+func TestTriangles(t *testing.T) {
+	ts, err := Triangles(CompleteGraph(5))
+	if err != nil {
+		panic(err.Error())
+	}
+	for v, c := range ts {
+		if c != 6 {
+			panic(fmt.Sprintf("vertex %d of K5 should be in 6 triangles,but get %d", v, c))
+		}
+	}
+	n, err := CountCycles(CompleteGraph(5), 3)
+	if err != nil {
+		panic(err.Error())
+	}
+	var sum int
+	for _, c := range ts {
+		sum += c
+	}
+	if sum/3 != n {
+		panic(fmt.Sprintf("triangle number should be %d,but get %d", n, sum/3))
+	}
+
+	cc, err := LocalClusteringCoefficients(CompleteGraph(5))
+	if err != nil {
+		panic(err.Error())
+	}
+	for v, c := range cc {
+		if !floatEqual(c, 1) {
+			panic(fmt.Sprintf("clustering coefficient of %d should be 1,but get %v", v, c))
+		}
+	}
+	// a triangle 0-1-2 with a pendant vertex 3 on 0.
+	g := NewGraph[int, int](false, "")
+	for i := 0; i < 4; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, e := range [][2]int{{0, 1}, {1, 2}, {2, 0}, {0, 3}} {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Head: e[0], Tail: e[1]})
+	}
+	avg, err := AverageClusteringCoefficient(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(avg, (1.0/3+1+1+0)/4) {
+		panic(fmt.Sprintf("wrong average clustering coefficient %v", avg))
+	}
+	gc, err := GlobalClusteringCoefficient(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if !floatEqual(gc, 3.0/5) {
+		panic(fmt.Sprintf("wrong global clustering coefficient %v", gc))
+	}
+}
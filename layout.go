@@ -0,0 +1,343 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+// Calculate straight-line drawing coordinates of a planar graph by the de Fraysseix-Pach-Pollack algorithm,
+// in the linear time implementation of Chrobak and Payne. All the vertexes are placed on a (2n-4)*(n-2) integer grid,
+// and no two edges cross. The direction of arcs is ignored, loops and parallel edges are drawn as the same segment.
+// If g is not planar, an error will be returned.
+func PlanarLayout[K comparable, W number](g Graph[K, W]) (map[K][2]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	adj := ig.simpleNeighbours()
+	p := &planarTestLR[int, int]{g: planarGraph(adj)}
+	if !p.planarity(true) {
+		return nil, errNotPlanar
+	}
+	n := len(adj)
+	res := make(map[K][2]float64, n)
+	if n < 4 {
+		// any triangle is fine.
+		corners := [][2]float64{{0, 0}, {2, 0}, {1, 1}}
+		for v, k := range ig.keys {
+			res[k] = corners[v]
+		}
+		return res, nil
+	}
+	emb := newRotationSystem(n)
+	for v, ns := range p.rotation() {
+		for i, w := range ns {
+			if i == 0 {
+				emb.addFirst(v, w)
+			} else {
+				emb.addAfter(v, w, ns[i-1])
+			}
+		}
+	}
+	for v, xy := range fppLayout(emb, emb.triangulate()) {
+		res[ig.keys[v]] = [2]float64{float64(xy[0]), float64(xy[1])}
+	}
+	return res, nil
+}
+
+/*
+Add edges to the embedding until every face is a triangle, return the vertexes of a face which can be the outer face.
+
+Connected components are connected first, then every face is walked, when a vertex is met the second time
+(i.e. it is a cut vertex), an edge which cuts off the corner is added, so the face becomes a cycle.
+At last every face is triangulated by adding edges (v1,v3) along its boundary v1,v2,v3,...,
+if (v1,v3) already exists (outside the face) the walk moves one step further instead.
+*/
+func (r *rotationSystem) triangulate() [3]int {
+	n := len(r.first)
+	// connect components.
+	comp := make([]int, n)
+	for i := range comp {
+		comp[i] = -1
+	}
+	for s := 0; s < n; s++ {
+		if comp[s] != -1 {
+			continue
+		}
+		comp[s] = s
+		queue := []int{s}
+		for len(queue) > 0 {
+			v := queue[0]
+			queue = queue[1:]
+			for _, w := range r.neighbours(v) {
+				if comp[w] == -1 {
+					comp[w] = s
+					queue = append(queue, w)
+				}
+			}
+		}
+		if s > 0 {
+			r.connect(0, s)
+		}
+	}
+	// make biconnected.
+	counted := make(map[[2]int]bool)
+	var faces [][]int
+	for v := 0; v < n; v++ {
+		for _, w := range r.neighbours(v) {
+			if f := r.makeBiconnected(v, w, counted); f != nil {
+				faces = append(faces, f)
+			}
+		}
+	}
+	for _, f := range faces {
+		r.triangulateFace(f[0], f[1])
+	}
+	v1, v2 := faces[0][0], faces[0][1]
+	return [3]int{v1, v2, r.ccw[v2][v1]}
+}
+
+// connect adds an edge between two vertexes in different components.
+func (r *rotationSystem) connect(v, w int) {
+	for _, e := range [][2]int{{v, w}, {w, v}} {
+		if r.first[e[0]] == -1 {
+			r.addFirst(e[0], e[1])
+		} else {
+			r.addAfter(e[0], e[1], r.first[e[0]])
+		}
+	}
+}
+
+// makeBiconnected walks the face of half edge (v,w) and adds edges to visit every vertex once,
+// returns the vertexes of the face, or nil if the face has been walked.
+func (r *rotationSystem) makeBiconnected(v, w int, counted map[[2]int]bool) []int {
+	if counted[[2]int{v, w}] {
+		return nil
+	}
+	counted[[2]int{v, w}] = true
+	face := []int{v}
+	inFace := map[int]bool{v: true}
+	v1, v2 := v, w
+	_, v3 := r.nextFaceEdge(v1, v2)
+	for v2 != v || v3 != w {
+		if inFace[v2] {
+			// v2 is visited twice, add edge (v1,v3).
+			r.addAfter(v1, v3, v2)
+			r.addBefore(v3, v1, v2)
+			counted[[2]int{v2, v3}] = true
+			counted[[2]int{v3, v1}] = true
+			v2 = v1
+		} else {
+			inFace[v2] = true
+			face = append(face, v2)
+		}
+		v1 = v2
+		v2, v3 = r.nextFaceEdge(v2, v3)
+		counted[[2]int{v1, v2}] = true
+	}
+	return face
+}
+
+// triangulateFace adds edges in the face of half edge (v1,v2), whose boundary is a cycle.
+func (r *rotationSystem) triangulateFace(v1, v2 int) {
+	_, v3 := r.nextFaceEdge(v1, v2)
+	_, v4 := r.nextFaceEdge(v2, v3)
+	if v1 == v2 || v1 == v3 {
+		return
+	}
+	for v1 != v4 {
+		if r.has(v1, v3) {
+			v1, v2, v3 = v2, v3, v4
+		} else {
+			r.addAfter(v1, v3, v2)
+			r.addBefore(v3, v1, v2)
+			v2, v3 = v3, v4
+		}
+		_, v4 = r.nextFaceEdge(v2, v3)
+	}
+}
+
+/*
+Calculate a canonical ordering of a triangulation with outer face (v1,v2,vn): v1,v2,...,vn such that for k >= 3,
+the subgraph Gk induced by v1,...,vk is biconnected and its outer face contains edge (v1,v2),
+and vk+1 lies on the outer face of Gk+1, whose neighbours in Gk form a subpath of the outer face of Gk.
+
+The ordering is built backwards by removing vertexes from the outer face, a vertex can be removed if it is not incident to a chord
+(an edge between two vertexes of the outer face which is not on the outer face). Return the vertexes and their neighbours
+on the outer face in order (wp,...,wq) when they are removed.
+*/
+func (r *rotationSystem) canonicalOrdering(outer [3]int) ([]int, [][]int) {
+	n := len(r.first)
+	v1, v2, v3 := outer[0], outer[1], outer[2]
+	// cwNbr and ccwNbr record the neighbours on the outer face, -1 means the vertex has not been on the outer face.
+	cwNbr, ccwNbr := make([]int, n), make([]int, n)
+	for i := 0; i < n; i++ {
+		cwNbr[i], ccwNbr[i] = -1, -1
+	}
+	ccwNbr[v2], ccwNbr[v3] = v3, v1
+	cwNbr[v1], cwNbr[v3] = v3, v2
+	marked := make([]bool, n)
+	chords := make([]int, n)
+	ready := make([]bool, n)
+	var candidates []int
+	pick := func(v int) {
+		ready[v] = true
+		candidates = append(candidates, v)
+	}
+	isOuterNbr := func(x, y int) bool {
+		if ccwNbr[x] == -1 {
+			return cwNbr[x] == y
+		}
+		if cwNbr[x] == -1 {
+			return ccwNbr[x] == y
+		}
+		return ccwNbr[x] == y || cwNbr[x] == y
+	}
+	onOuter := func(x int) bool {
+		return !marked[x] && (ccwNbr[x] != -1 || x == v1)
+	}
+	for _, v := range outer {
+		pick(v)
+		for _, w := range r.neighbours(v) {
+			if onOuter(w) && !isOuterNbr(v, w) {
+				chords[v]++
+				ready[v] = false
+			}
+		}
+	}
+	ready[v1], ready[v2] = false, false
+	order := make([]int, n)
+	contour := make([][]int, n)
+	order[0], order[1] = v1, v2
+	for k := n - 1; k > 1; k-- {
+		var v int
+		for {
+			v = candidates[len(candidates)-1]
+			candidates = candidates[:len(candidates)-1]
+			if ready[v] {
+				break
+			}
+		}
+		ready[v], marked[v] = false, true
+		wp, wq := -1, -1
+		for _, w := range r.neighbours(v) {
+			if marked[w] || !onOuter(w) {
+				continue
+			}
+			if w == v1 {
+				wp = v1
+			} else if w == v2 {
+				wq = v2
+			} else if cwNbr[w] == v {
+				wp = w
+			} else {
+				wq = w
+			}
+			if wp != -1 && wq != -1 {
+				break
+			}
+		}
+		// the neighbours of v between wp and wq become the outer face.
+		path := []int{wp}
+		for w := wp; w != wq; {
+			next := r.ccw[v][w]
+			path = append(path, next)
+			cwNbr[w], ccwNbr[next] = next, w
+			w = next
+		}
+		if len(path) == 2 {
+			// the chord (wp,wq) is on the outer face now.
+			for _, w := range path {
+				if chords[w]--; chords[w] == 0 {
+					pick(w)
+				}
+			}
+		} else {
+			inner := make(map[int]bool)
+			for _, w := range path[1 : len(path)-1] {
+				inner[w] = true
+			}
+			for _, w := range path[1 : len(path)-1] {
+				pick(w)
+				for _, x := range r.neighbours(w) {
+					if onOuter(x) && !isOuterNbr(w, x) {
+						chords[w]++
+						ready[w] = false
+						if !inner[x] {
+							chords[x]++
+							ready[x] = false
+						}
+					}
+				}
+			}
+		}
+		order[k], contour[k] = v, path
+	}
+	return order, contour
+}
+
+/*
+de Fraysseix-Pach-Pollack algorithm: vertexes are added in canonical order, v1,v2,v3 are placed at (0,0),(2,0),(1,1).
+When vk is added with neighbours wp,...,wq on the outer face, wp+1,...,wq-1 are shifted right by 1, wq,... are shifted right by 2,
+then vk is placed at the intersection of the line with slope 1 from wp and the line with slope -1 from wq.
+A vertex is always shifted with the vertexes covered by it, so every vertex records its x offset from its parent in a binary tree,
+where the right child is the next vertex on the outer face and the left child is the first covered vertex (Chrobak and Payne).
+*/
+func fppLayout(r *rotationSystem, outer [3]int) [][2]int {
+	n := len(r.first)
+	order, contour := r.canonicalOrdering(outer)
+	left, right := make([]int, n), make([]int, n)
+	dx, y := make([]int, n), make([]int, n)
+	for i := 0; i < n; i++ {
+		left[i], right[i] = -1, -1
+	}
+	v1, v2, v3 := order[0], order[1], order[2]
+	right[v1], right[v3] = v3, v2
+	dx[v2], dx[v3], y[v3] = 1, 1, 1
+	for k := 3; k < n; k++ {
+		vk, ws := order[k], contour[k]
+		wp, wp1, wq := ws[0], ws[1], ws[len(ws)-1]
+		// stretch gaps.
+		dx[wp1]++
+		dx[wq]++
+		d := 0
+		for _, w := range ws[1:] {
+			d += dx[w]
+		}
+		dx[vk] = (d - y[wp] + y[wq]) / 2
+		y[vk] = (d + y[wp] + y[wq]) / 2
+		dx[wq] = d - dx[vk]
+		if len(ws) > 2 {
+			dx[wp1] -= dx[vk]
+			left[vk] = wp1
+			right[ws[len(ws)-2]] = -1
+		} else {
+			left[vk] = -1
+		}
+		right[wp], right[vk] = vk, wq
+	}
+	pos := make([][2]int, n)
+	stack := []int{v1}
+	for len(stack) > 0 {
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, c := range []int{left[v], right[v]} {
+			if c != -1 {
+				pos[c] = [2]int{pos[v][0] + dx[c], y[c]}
+				stack = append(stack, c)
+			}
+		}
+	}
+	return pos
+}
@@ -0,0 +1,127 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// checkStraightLineDrawing checks that vertexes have distinct positions, no vertex lies inside an edge, and no two edges cross.
+func checkStraightLineDrawing(g Graph[int, int], pos map[int][2]float64) {
+	orient := func(a, b, c [2]float64) float64 {
+		return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+	}
+	// p lies on segment ab (p is not an end).
+	onSegment := func(a, b, p [2]float64) bool {
+		return orient(a, b, p) == 0 && min(a[0], b[0]) <= p[0] && p[0] <= max(a[0], b[0]) &&
+			min(a[1], b[1]) <= p[1] && p[1] <= max(a[1], b[1])
+	}
+	vs := g.AllVertexes()
+	for i := range vs {
+		for j := i + 1; j < len(vs); j++ {
+			if pos[vs[i].Key] == pos[vs[j].Key] {
+				panic(fmt.Sprintf("vertex %d and %d have the same position", vs[i].Key, vs[j].Key))
+			}
+		}
+	}
+	var es [][2]int
+	for _, e := range g.AllEdges() {
+		if e.Head != e.Tail {
+			es = append(es, [2]int{e.Head, e.Tail})
+		}
+	}
+	for _, e := range es {
+		a, b := pos[e[0]], pos[e[1]]
+		for _, v := range vs {
+			if v.Key != e[0] && v.Key != e[1] && onSegment(a, b, pos[v.Key]) {
+				panic(fmt.Sprintf("vertex %d lies on edge %v", v.Key, e))
+			}
+		}
+	}
+	for i, e := range es {
+		for _, f := range es[i+1:] {
+			if e[0] == f[0] || e[0] == f[1] || e[1] == f[0] || e[1] == f[1] {
+				continue
+			}
+			a, b, c, d := pos[e[0]], pos[e[1]], pos[f[0]], pos[f[1]]
+			if orient(a, b, c)*orient(a, b, d) < 0 && orient(c, d, a)*orient(c, d, b) < 0 {
+				panic(fmt.Sprintf("edge %v and %v cross", e, f))
+			}
+		}
+	}
+}
+
+func TestPlanarLayout(t *testing.T) {
+	var es [][3]int
+	for i := 0; i < 4; i++ {
+		es = append(es, [3]int{i, i, 1})
+		for j := 0; j < 4; j++ {
+			if i != j {
+				es = append(es, [3]int{i, j, 1}, [3]int{j, i, 1})
+			}
+		}
+	}
+	graphs := []Graph[int, int]{
+		CompleteGraph(1),
+		CompleteGraph(3),
+		CompleteGraph(4),
+		CompleteBipartite(2, 3),
+		Hypercube(3),
+		testMultigraph(false, 4, es),
+		testMultigraph(true, 4, es),
+		testMultigraph(false, 6, [][3]int{{0, 1, 1}, {2, 3, 1}}),
+	}
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		rows, cols := 2+r.Intn(10), 2+r.Intn(10)
+		graphs = append(graphs, testMultigraph(false, rows*cols, testGridGraph(r, rows, cols)))
+	}
+	for round := 0; round < 500; round++ {
+		graphs = append(graphs, testRandomGraph(r, 3+r.Intn(20), 0.05+0.3*r.Float64()))
+	}
+	planar := 0
+	for _, g := range graphs {
+		pos, err := PlanarLayout(g)
+		if !CheckPlanarity(g) {
+			if err == nil {
+				panic(fmt.Sprintf("graph %v is not planar", g.AllEdges()))
+			}
+			continue
+		}
+		if err != nil {
+			panic(err.Error())
+		}
+		n := float64(g.Order())
+		for _, xy := range pos {
+			if n >= 3 && (xy[0] < 0 || xy[0] > 2*n-4 || xy[1] < 0 || xy[1] > n-2) {
+				panic(fmt.Sprintf("position %v is out of the grid", xy))
+			}
+		}
+		if len(pos) != g.Order() {
+			panic(fmt.Sprintf("expect %d positions, get %d", g.Order(), len(pos)))
+		}
+		checkStraightLineDrawing(g, pos)
+		planar++
+	}
+	if _, err := PlanarLayout(CompleteGraph(5)); err == nil {
+		panic("K5 is not planar")
+	}
+	fmt.Printf("check %d planar drawings\n", planar)
+	fmt.Println("=======> test planar layout pass")
+}
@@ -1296,3 +1296,614 @@ func (mm *maxMatchingBlossom[K, W]) addEdge(u, v int) {
 type maxMatchingMV[K comparable, W number] struct {
 	graph Graph[K, W]
 }
+
+// Calculate a maximum weight matching of any graph by the primal-dual blossom algorithm, the weight of an edge is Edge.Weight.
+// If maxCardinality is true, the matching is of maximum weight among the matchings of maximum cardinality.
+// The direction of arcs is ignored and loops are skipped.
+func MaxWeightMatching[K comparable, W number](g Graph[K, W], maxCardinality bool) ([]Edge[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	var edges []weightedPair
+	var keys []int
+	for k, e := range ig.edges {
+		if i, j := ig.idx[e.Tail], ig.idx[e.Head]; i != j {
+			edges = append(edges, weightedPair{i, j, float64(e.Weight)})
+			keys = append(keys, k)
+		}
+	}
+	mate := maxWeightMatching(ig.order(), edges, maxCardinality)
+	var res []Edge[K, W]
+	for v, k := range mate {
+		if k >= 0 && v == edges[k].i {
+			res = append(res, ig.edges[keys[k]])
+		}
+	}
+	return res, nil
+}
+
+// Calculate a perfect matching with minimum total weight, an error will be returned if there is no perfect matching.
+// Negative weights are allowed, the direction of arcs is ignored and loops are skipped.
+func MinWeightPerfectMatching[K comparable, W number](g Graph[K, W]) ([]Edge[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	if n%2 == 1 {
+		return nil, errMatchNotExists
+	}
+	var edges []weightedPair
+	var keys []int
+	maxW := math.Inf(-1)
+	for k, e := range ig.edges {
+		if i, j := ig.idx[e.Tail], ig.idx[e.Head]; i != j {
+			edges = append(edges, weightedPair{i, j, float64(e.Weight)})
+			keys = append(keys, k)
+			maxW = math.Max(maxW, float64(e.Weight))
+		}
+	}
+	// a maximum cardinality matching maximizing the sum of (maxW+1-w) minimizes the sum of w among perfect matchings.
+	for i := range edges {
+		edges[i].w = maxW + 1 - edges[i].w
+	}
+	mate := maxWeightMatching(n, edges, true)
+	var res []Edge[K, W]
+	for v, k := range mate {
+		if k < 0 {
+			return nil, errMatchNotExists
+		}
+		if v == edges[k].i {
+			res = append(res, ig.edges[keys[k]])
+		}
+	}
+	return res, nil
+}
+
+type weightedPair struct {
+	i, j int
+	w    float64
+}
+
+// maxWeightMatching returns the index of the matched edge of every vertex (-1 if unmatched).
+func maxWeightMatching(n int, edges []weightedPair, maxCardinality bool) []int {
+	m := newWeightedBlossom(n, edges, maxCardinality)
+	m.solve()
+	mate := make([]int, n)
+	for v := range mate {
+		mate[v] = -1
+		if m.mate[v] >= 0 {
+			mate[v] = m.mate[v] / 2
+		}
+	}
+	return mate
+}
+
+/*
+weightedBlossom is the primal-dual blossom algorithm of Edmonds for maximum weight matching in O(n^3),
+following the implementation of Joris van Rantwijk (based on Galil, "Efficient algorithms for finding
+maximum matching in graphs", 1986).
+
+Every vertex v has a dual variable u(v) and every non-trivial blossom b has a dual variable z(b),
+the slack of edge (i,j) is u(i)+u(j)-2w(i,j) (the blossom duals are doubled), an edge is tight if its slack is zero.
+With integer weights (below 2^52) all the duals and slacks stay integers, so they are compared exactly.
+In every stage alternating trees are grown from the single vertexes over tight edges,
+S-blossoms (outer) are formed by edges between two S-vertexes of the same tree, and an augmenting path is found
+by such an edge between different trees. If no tight edge can be used, the duals are changed by the largest delta
+which keeps them feasible: delta1 makes a dual variable of a vertex zero (the stage ends),
+delta2 makes an edge between an S-vertex and a free vertex tight, delta3 makes an edge between S-blossoms tight,
+and delta4 makes the dual of a T-blossom zero, so it is expanded.
+
+Edge k has endpoints 2k (its i) and 2k+1 (its j), the remote endpoint of p is p^1.
+mate[v] is the remote endpoint of the matched edge of v, and labelend[b] is the endpoint through which b got its label.
+Indexes 0..n-1 are vertexes (trivial blossoms) and n..2n-1 are non-trivial blossoms.
+*/
+type weightedBlossom struct {
+	n              int
+	edges          []weightedPair
+	maxCardinality bool
+	endpoint       []int
+	neighbend      [][]int
+	mate           []int
+	label          []int // 0: free, 1: S-vertex/blossom, 2: T-vertex/blossom, 5: marked in scanBlossom
+	labelend       []int
+	inblossom      []int // the top-level blossom of every vertex
+	blossomparent  []int
+	blossomchilds  [][]int // the sub-blossoms of b, along the cycle starting from the base
+	blossombase    []int
+	blossomendps   [][]int // blossomendps[b][i] is the endpoint linking blossomchilds[b][i] and blossomchilds[b][i+1]
+	bestedge       []int   // the least-slack edge to a different S-blossom (or from a free vertex to S-vertex)
+	blossombest    [][]int // the least-slack edges to the neighbouring S-blossoms of a S-blossom
+	unused         []int
+	dualvar        []float64
+	allowedge      []bool
+	queue          []int
+}
+
+func newWeightedBlossom(n int, edges []weightedPair, maxCardinality bool) *weightedBlossom {
+	b := &weightedBlossom{n: n, edges: edges, maxCardinality: maxCardinality}
+	var maxW float64
+	for _, e := range edges {
+		maxW = math.Max(maxW, e.w)
+	}
+	b.endpoint = make([]int, 2*len(edges))
+	b.neighbend = make([][]int, n)
+	for k, e := range edges {
+		b.endpoint[2*k], b.endpoint[2*k+1] = e.i, e.j
+		b.neighbend[e.i] = append(b.neighbend[e.i], 2*k+1)
+		b.neighbend[e.j] = append(b.neighbend[e.j], 2*k)
+	}
+	b.mate = make([]int, n)
+	b.label = make([]int, 2*n)
+	b.labelend = make([]int, 2*n)
+	b.inblossom = make([]int, n)
+	b.blossomparent = make([]int, 2*n)
+	b.blossomchilds = make([][]int, 2*n)
+	b.blossombase = make([]int, 2*n)
+	b.blossomendps = make([][]int, 2*n)
+	b.bestedge = make([]int, 2*n)
+	b.blossombest = make([][]int, 2*n)
+	b.dualvar = make([]float64, 2*n)
+	b.allowedge = make([]bool, len(edges))
+	for v := 0; v < 2*n; v++ {
+		b.labelend[v], b.blossomparent[v], b.bestedge[v], b.blossombase[v] = -1, -1, -1, -1
+		if v < n {
+			b.mate[v], b.inblossom[v], b.blossombase[v], b.dualvar[v] = -1, v, v, maxW
+		} else {
+			b.unused = append(b.unused, v)
+		}
+	}
+	return b
+}
+
+func (b *weightedBlossom) slack(k int) float64 {
+	e := b.edges[k]
+	return b.dualvar[e.i] + b.dualvar[e.j] - 2*e.w
+}
+
+func (b *weightedBlossom) leaves(t int, f func(v int)) {
+	if t < b.n {
+		f(t)
+		return
+	}
+	for _, c := range b.blossomchilds[t] {
+		b.leaves(c, f)
+	}
+}
+
+// assignLabel labels the top-level blossom containing w with t (reached through endpoint p),
+// the mate of the base of a T-blossom becomes S.
+func (b *weightedBlossom) assignLabel(w, t, p int) {
+	bw := b.inblossom[w]
+	b.label[w], b.label[bw] = t, t
+	b.labelend[w], b.labelend[bw] = p, p
+	b.bestedge[w], b.bestedge[bw] = -1, -1
+	if t == 1 {
+		b.leaves(bw, func(v int) { b.queue = append(b.queue, v) })
+		return
+	}
+	base := b.blossombase[bw]
+	b.assignLabel(b.endpoint[b.mate[base]], 1, b.mate[base]^1)
+}
+
+// scanBlossom traces back from v and w, returns the base of the new blossom or -1 if an augmenting path is found.
+func (b *weightedBlossom) scanBlossom(v, w int) int {
+	var path []int
+	base := -1
+	for v != -1 || w != -1 {
+		bv := b.inblossom[v]
+		if b.label[bv]&4 != 0 {
+			base = b.blossombase[bv]
+			break
+		}
+		path = append(path, bv)
+		b.label[bv] = 5
+		if b.labelend[bv] == -1 {
+			// the root of the tree.
+			v = -1
+		} else {
+			v = b.endpoint[b.labelend[bv]]
+			v = b.endpoint[b.labelend[b.inblossom[v]]]
+		}
+		if w != -1 {
+			v, w = w, v
+		}
+	}
+	for _, t := range path {
+		b.label[t] = 1
+	}
+	return base
+}
+
+// addBlossom contracts the odd cycle closed by edge k into a new S-blossom with the given base.
+func (b *weightedBlossom) addBlossom(base, k int) {
+	v, w := b.edges[k].i, b.edges[k].j
+	bb, bv, bw := b.inblossom[base], b.inblossom[v], b.inblossom[w]
+	nb := b.unused[len(b.unused)-1]
+	b.unused = b.unused[:len(b.unused)-1]
+	b.blossombase[nb] = base
+	b.blossomparent[nb] = -1
+	b.blossomparent[bb] = nb
+	var path, endps []int
+	for bv != bb {
+		b.blossomparent[bv] = nb
+		path = append(path, bv)
+		endps = append(endps, b.labelend[bv])
+		v = b.endpoint[b.labelend[bv]]
+		bv = b.inblossom[v]
+	}
+	path = append(path, bb)
+	reverseInts(path)
+	reverseInts(endps)
+	endps = append(endps, 2*k)
+	for bw != bb {
+		b.blossomparent[bw] = nb
+		path = append(path, bw)
+		endps = append(endps, b.labelend[bw]^1)
+		w = b.endpoint[b.labelend[bw]]
+		bw = b.inblossom[w]
+	}
+	b.blossomchilds[nb], b.blossomendps[nb] = path, endps
+	b.label[nb] = 1
+	b.labelend[nb] = b.labelend[bb]
+	b.dualvar[nb] = 0
+	b.leaves(nb, func(v int) {
+		if b.label[b.inblossom[v]] == 2 {
+			// T-vertexes become S-vertexes.
+			b.queue = append(b.queue, v)
+		}
+		b.inblossom[v] = nb
+	})
+	// compute the least-slack edges to the neighbouring S-blossoms.
+	bestedgeto := make([]int, 2*b.n)
+	for i := range bestedgeto {
+		bestedgeto[i] = -1
+	}
+	for _, sb := range path {
+		var nblists [][]int
+		if b.blossombest[sb] == nil {
+			b.leaves(sb, func(v int) {
+				list := make([]int, len(b.neighbend[v]))
+				for i, p := range b.neighbend[v] {
+					list[i] = p / 2
+				}
+				nblists = append(nblists, list)
+			})
+		} else {
+			nblists = [][]int{b.blossombest[sb]}
+		}
+		for _, list := range nblists {
+			for _, k := range list {
+				j := b.edges[k].j
+				if b.inblossom[j] == nb {
+					j = b.edges[k].i
+				}
+				bj := b.inblossom[j]
+				if bj != nb && b.label[bj] == 1 && (bestedgeto[bj] == -1 || b.slack(k) < b.slack(bestedgeto[bj])) {
+					bestedgeto[bj] = k
+				}
+			}
+		}
+		b.blossombest[sb] = nil
+		b.bestedge[sb] = -1
+	}
+	best := []int{}
+	for _, k := range bestedgeto {
+		if k != -1 {
+			best = append(best, k)
+		}
+	}
+	b.blossombest[nb] = best
+	b.bestedge[nb] = -1
+	for _, k := range best {
+		if b.bestedge[nb] == -1 || b.slack(k) < b.slack(b.bestedge[nb]) {
+			b.bestedge[nb] = k
+		}
+	}
+}
+
+// expandBlossom expands blossom t, at the end of a stage (endstage) its sub-blossoms with zero dual are expanded recursively,
+// otherwise t is a T-blossom and the labels are restored along the even path from the entry to the base.
+func (b *weightedBlossom) expandBlossom(t int, endstage bool) {
+	for _, s := range b.blossomchilds[t] {
+		b.blossomparent[s] = -1
+		if s < b.n {
+			b.inblossom[s] = s
+		} else if endstage && b.dualvar[s] == 0 {
+			b.expandBlossom(s, endstage)
+		} else {
+			b.leaves(s, func(v int) { b.inblossom[v] = s })
+		}
+	}
+	if !endstage && b.label[t] == 2 {
+		childs, endps := b.blossomchilds[t], b.blossomendps[t]
+		l := len(childs)
+		at := func(s []int, j int) int { return s[((j%l)+l)%l] }
+		entrychild := b.inblossom[b.endpoint[b.labelend[t]^1]]
+		j := indexOf(childs, entrychild)
+		jstep, endptrick := -1, 1
+		if j&1 != 0 {
+			j -= l
+			jstep, endptrick = 1, 0
+		}
+		p := b.labelend[t]
+		for j != 0 {
+			// relabel the T-sub-blossom and its mate.
+			b.label[b.endpoint[p^1]] = 0
+			b.label[b.endpoint[at(endps, j-endptrick)^endptrick^1]] = 0
+			b.assignLabel(b.endpoint[p^1], 2, p)
+			b.allowedge[at(endps, j-endptrick)/2] = true
+			j += jstep
+			p = at(endps, j-endptrick) ^ endptrick
+			b.allowedge[p/2] = true
+			j += jstep
+		}
+		bv := at(childs, j)
+		b.label[b.endpoint[p^1]], b.label[bv] = 2, 2
+		b.labelend[b.endpoint[p^1]], b.labelend[bv] = p, p
+		b.bestedge[bv] = -1
+		j += jstep
+		for at(childs, j) != entrychild {
+			bv = at(childs, j)
+			if b.label[bv] == 1 {
+				j += jstep
+				continue
+			}
+			// a sub-blossom reached from outside is labelled T.
+			v := -1
+			b.leaves(bv, func(x int) {
+				if v < 0 && b.label[x] != 0 {
+					v = x
+				}
+			})
+			if v >= 0 {
+				b.label[v] = 0
+				b.label[b.endpoint[b.mate[b.blossombase[bv]]]] = 0
+				b.assignLabel(v, 2, b.labelend[v])
+			}
+			j += jstep
+		}
+	}
+	b.label[t], b.labelend[t] = -1, -1
+	b.blossomchilds[t], b.blossomendps[t] = nil, nil
+	b.blossombase[t] = -1
+	b.blossombest[t] = nil
+	b.bestedge[t] = -1
+	b.unused = append(b.unused, t)
+}
+
+// augmentBlossom swaps the matched and unmatched edges along the even path from v to the base of blossom t,
+// v becomes the new base.
+func (b *weightedBlossom) augmentBlossom(t, v int) {
+	s := v
+	for b.blossomparent[s] != t {
+		s = b.blossomparent[s]
+	}
+	if s >= b.n {
+		b.augmentBlossom(s, v)
+	}
+	childs, endps := b.blossomchilds[t], b.blossomendps[t]
+	l := len(childs)
+	at := func(x []int, j int) int { return x[((j%l)+l)%l] }
+	i := indexOf(childs, s)
+	j := i
+	jstep, endptrick := -1, 1
+	if i&1 != 0 {
+		j -= l
+		jstep, endptrick = 1, 0
+	}
+	for j != 0 {
+		j += jstep
+		s = at(childs, j)
+		p := at(endps, j-endptrick) ^ endptrick
+		if s >= b.n {
+			b.augmentBlossom(s, b.endpoint[p])
+		}
+		j += jstep
+		s = at(childs, j)
+		if s >= b.n {
+			b.augmentBlossom(s, b.endpoint[p^1])
+		}
+		b.mate[b.endpoint[p]] = p ^ 1
+		b.mate[b.endpoint[p^1]] = p
+	}
+	// rotate the sub-blossoms so that the new base is the first.
+	b.blossomchilds[t] = append(append([]int{}, childs[i:]...), childs[:i]...)
+	b.blossomendps[t] = append(append([]int{}, endps[i:]...), endps[:i]...)
+	b.blossombase[t] = b.blossombase[b.blossomchilds[t][0]]
+}
+
+// augmentMatching augments the matching along the path through edge k.
+func (b *weightedBlossom) augmentMatching(k int) {
+	for _, sp := range [2][2]int{{b.edges[k].i, 2*k + 1}, {b.edges[k].j, 2 * k}} {
+		s, p := sp[0], sp[1]
+		for {
+			bs := b.inblossom[s]
+			if bs >= b.n {
+				b.augmentBlossom(bs, s)
+			}
+			b.mate[s] = p
+			if b.labelend[bs] == -1 {
+				// reached the root.
+				break
+			}
+			t := b.endpoint[b.labelend[bs]]
+			bt := b.inblossom[t]
+			s = b.endpoint[b.labelend[bt]]
+			j := b.endpoint[b.labelend[bt]^1]
+			if bt >= b.n {
+				b.augmentBlossom(bt, j)
+			}
+			b.mate[j] = b.labelend[bt]
+			p = b.labelend[bt] ^ 1
+		}
+	}
+}
+
+func (b *weightedBlossom) solve() {
+	n := b.n
+	for stage := 0; stage < n; stage++ {
+		for i := range b.label {
+			b.label[i] = 0
+			b.bestedge[i] = -1
+			if i >= n {
+				b.blossombest[i] = nil
+			}
+		}
+		for i := range b.allowedge {
+			b.allowedge[i] = false
+		}
+		b.queue = b.queue[:0]
+		for v := 0; v < n; v++ {
+			if b.mate[v] == -1 && b.label[b.inblossom[v]] == 0 {
+				b.assignLabel(v, 1, -1)
+			}
+		}
+		augmented := false
+		for {
+			for len(b.queue) != 0 && !augmented {
+				v := b.queue[len(b.queue)-1]
+				b.queue = b.queue[:len(b.queue)-1]
+				for _, p := range b.neighbend[v] {
+					k, w := p/2, b.endpoint[p]
+					if b.inblossom[v] == b.inblossom[w] {
+						continue
+					}
+					var kslack float64
+					if !b.allowedge[k] {
+						if kslack = b.slack(k); kslack <= 0 {
+							b.allowedge[k] = true
+						}
+					}
+					switch {
+					case b.allowedge[k] && b.label[b.inblossom[w]] == 0:
+						// grow the tree by a T-vertex.
+						b.assignLabel(w, 2, p^1)
+					case b.allowedge[k] && b.label[b.inblossom[w]] == 1:
+						if base := b.scanBlossom(v, w); base >= 0 {
+							b.addBlossom(base, k)
+						} else {
+							b.augmentMatching(k)
+							augmented = true
+						}
+					case b.allowedge[k] && b.label[w] == 0:
+						// w is inside a T-blossom but not yet reached from outside.
+						b.label[w] = 2
+						b.labelend[w] = p ^ 1
+					case !b.allowedge[k] && b.label[b.inblossom[w]] == 1:
+						if bv := b.inblossom[v]; b.bestedge[bv] == -1 || kslack < b.slack(b.bestedge[bv]) {
+							b.bestedge[bv] = k
+						}
+					case !b.allowedge[k] && b.label[w] == 0:
+						if b.bestedge[w] == -1 || kslack < b.slack(b.bestedge[w]) {
+							b.bestedge[w] = k
+						}
+					}
+					if augmented {
+						break
+					}
+				}
+			}
+			if augmented {
+				break
+			}
+
+			// no augmenting path with the tight edges, change the duals.
+			deltatype, delta, deltaedge, deltablossom := -1, 0.0, -1, -1
+			if !b.maxCardinality {
+				deltatype, delta = 1, math.Inf(1)
+				for v := 0; v < n; v++ {
+					delta = math.Min(delta, b.dualvar[v])
+				}
+			}
+			for v := 0; v < n; v++ {
+				if b.label[b.inblossom[v]] == 0 && b.bestedge[v] != -1 {
+					if d := b.slack(b.bestedge[v]); deltatype == -1 || d < delta {
+						deltatype, delta, deltaedge = 2, d, b.bestedge[v]
+					}
+				}
+			}
+			for t := 0; t < 2*n; t++ {
+				if b.blossomparent[t] == -1 && b.label[t] == 1 && b.bestedge[t] != -1 {
+					if d := b.slack(b.bestedge[t]) / 2; deltatype == -1 || d < delta {
+						deltatype, delta, deltaedge = 3, d, b.bestedge[t]
+					}
+				}
+			}
+			for t := n; t < 2*n; t++ {
+				if b.blossombase[t] >= 0 && b.blossomparent[t] == -1 && b.label[t] == 2 &&
+					(deltatype == -1 || b.dualvar[t] < delta) {
+					deltatype, delta, deltablossom = 4, b.dualvar[t], t
+				}
+			}
+			if deltatype == -1 {
+				// no further improvement is possible under maximum cardinality.
+				deltatype, delta = 1, math.Inf(1)
+				for v := 0; v < n; v++ {
+					delta = math.Min(delta, b.dualvar[v])
+				}
+				delta = math.Max(delta, 0)
+			}
+			for v := 0; v < n; v++ {
+				switch b.label[b.inblossom[v]] {
+				case 1:
+					b.dualvar[v] -= delta
+				case 2:
+					b.dualvar[v] += delta
+				}
+			}
+			for t := n; t < 2*n; t++ {
+				if b.blossombase[t] >= 0 && b.blossomparent[t] == -1 {
+					switch b.label[t] {
+					case 1:
+						b.dualvar[t] += delta
+					case 2:
+						b.dualvar[t] -= delta
+					}
+				}
+			}
+			if deltatype == 1 {
+				break
+			}
+			switch deltatype {
+			case 2:
+				b.allowedge[deltaedge] = true
+				i := b.edges[deltaedge].i
+				if b.label[b.inblossom[i]] == 0 {
+					i = b.edges[deltaedge].j
+				}
+				b.queue = append(b.queue, i)
+			case 3:
+				b.allowedge[deltaedge] = true
+				b.queue = append(b.queue, b.edges[deltaedge].i)
+			case 4:
+				b.expandBlossom(deltablossom, false)
+			}
+		}
+		if !augmented {
+			break
+		}
+		// expand the S-blossoms with zero dual at the end of the stage.
+		for t := n; t < 2*n; t++ {
+			if b.blossomparent[t] == -1 && b.blossombase[t] >= 0 && b.label[t] == 1 && b.dualvar[t] == 0 {
+				b.expandBlossom(t, true)
+			}
+		}
+	}
+}
+
+func reverseInts(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+func indexOf(s []int, x int) int {
+	for i, y := range s {
+		if y == x {
+			return i
+		}
+	}
+	return -1
+}
@@ -19,6 +19,8 @@ package graphlib
 import (
 	"flag"
 	"fmt"
+	"math"
+	"math/rand"
 	"testing"
 )
 
@@ -258,3 +260,223 @@ func TestMatching(t *testing.T) {
 	default:
 	}
 }
+
+// bruteForceWeightedMatching returns the best (cardinality, weight) of the matchings, the weight is maximized
+// after the cardinality if maxCardinality, or the minimum weight perfect matching is found if minimum.
+func bruteForceWeightedMatching[W number](n int, es [][2]int, ws []W, maxCardinality, minimum bool) (int, W) {
+	bestC, bestW := -1, W(0)
+	used := make([]bool, n)
+	var search func(k, c int, w W)
+	search = func(k, c int, w W) {
+		if k == len(es) {
+			better := false
+			switch {
+			case minimum:
+				better = 2*c == n && (bestC < 0 || w < bestW)
+			case maxCardinality:
+				better = c > bestC || (c == bestC && w > bestW)
+			default:
+				better = bestC < 0 || w > bestW
+			}
+			if better {
+				bestC, bestW = c, w
+			}
+			return
+		}
+		search(k+1, c, w)
+		if e := es[k]; e[0] != e[1] && !used[e[0]] && !used[e[1]] {
+			used[e[0]], used[e[1]] = true, true
+			search(k+1, c+1, w+ws[k])
+			used[e[0]], used[e[1]] = false, false
+		}
+	}
+	search(0, 0, 0)
+	return bestC, bestW
+}
+
+func TestWeightedMatching(t *testing.T) {
+	r := rand.New(rand.NewSource(50))
+	for round := 0; round < 1000; round++ {
+		n := 1 + r.Intn(8)
+		var es [][3]int
+		for k := r.Intn(2 * n); k > 0; k-- {
+			es = append(es, [3]int{r.Intn(n), r.Intn(n), r.Intn(20) - 5})
+		}
+		g := testMultigraph(round%3 == 0, n, es)
+		ends, ws := make([][2]int, len(es)), make([]int, len(es))
+		for i, e := range es {
+			ends[i], ws[i] = [2]int{e[0], e[1]}, e[2]
+		}
+		check := func(m []Edge[int, int]) (int, int) {
+			used := make(map[int]bool)
+			var w int
+			for _, e := range m {
+				if used[e.Head] || used[e.Tail] || e.Head == e.Tail {
+					panic(fmt.Sprintf("%v is not a matching", m))
+				}
+				used[e.Head], used[e.Tail] = true, true
+				w += e.Weight
+			}
+			return len(m), w
+		}
+		for _, maxCardinality := range []bool{false, true} {
+			m, err := MaxWeightMatching(g, maxCardinality)
+			if err != nil {
+				panic(err)
+			}
+			c, w := check(m)
+			wantC, wantW := bruteForceWeightedMatching(n, ends, ws, maxCardinality, false)
+			if w != wantW || (maxCardinality && c != wantC) {
+				panic(fmt.Sprintf("max weight matching of %v (max cardinality %v): expect %d/%d, got %d/%d %v",
+					es, maxCardinality, wantC, wantW, c, w, m))
+			}
+		}
+		m, err := MinWeightPerfectMatching(g)
+		wantC, wantW := bruteForceWeightedMatching(n, ends, ws, false, true)
+		if wantC < 0 {
+			if err != errMatchNotExists {
+				panic(fmt.Sprintf("%v has no perfect matching, got %v", es, m))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Sprintf("%v: %v", es, err))
+		}
+		if c, w := check(m); c != wantC || w != wantW {
+			panic(fmt.Sprintf("min weight perfect matching of %v: expect %d, got %d %v", es, wantW, w, m))
+		}
+	}
+	fmt.Println("=======> test weighted matching pass")
+}
+
+func TestWeightedMatchingFloat(t *testing.T) {
+	r := rand.New(rand.NewSource(51))
+	near := func(a, b float64) bool {
+		return math.Abs(a-b) <= 1e-9*math.Max(1, math.Abs(b))
+	}
+	for round := 0; round < 2000; round++ {
+		n := 2 + r.Intn(8)
+		g := NewGraph[int, float64](false, "")
+		for i := 0; i < n; i++ {
+			_ = g.AddVertex(Vertex[int, float64]{Key: i})
+		}
+		var (
+			ends [][2]int
+			ws   []float64
+		)
+		for k := r.Intn(3 * n); k > 0; k-- {
+			// sums like 0.1+0.2 and 0.3 are not exactly equal in floating point.
+			e, w := [2]int{r.Intn(n), r.Intn(n)}, float64(r.Intn(10))*0.1+float64(r.Intn(3))*0.7
+			if err := g.AddEdge(Edge[int, float64]{Key: len(ends), Tail: e[0], Head: e[1], Weight: w}); err != nil {
+				panic(err)
+			}
+			ends, ws = append(ends, e), append(ws, w)
+		}
+		sum := func(m []Edge[int, float64]) (int, float64) {
+			var w float64
+			for _, e := range m {
+				w += e.Weight
+			}
+			return len(m), w
+		}
+		for _, maxCardinality := range []bool{false, true} {
+			m, err := MaxWeightMatching(g, maxCardinality)
+			if err != nil {
+				panic(err)
+			}
+			c, w := sum(m)
+			wantC, wantW := bruteForceWeightedMatching(n, ends, ws, maxCardinality, false)
+			if !near(w, wantW) || (maxCardinality && c != wantC) {
+				panic(fmt.Sprintf("max weight matching of %v %v (max cardinality %v): expect %d/%v, got %d/%v",
+					ends, ws, maxCardinality, wantC, wantW, c, w))
+			}
+		}
+		m, err := MinWeightPerfectMatching(g)
+		wantC, wantW := bruteForceWeightedMatching(n, ends, ws, false, true)
+		if wantC < 0 {
+			if err != errMatchNotExists {
+				panic(fmt.Sprintf("%v has no perfect matching, got %v", ends, m))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Sprintf("%v: %v", ends, err))
+		}
+		if c, w := sum(m); c != wantC || !near(w, wantW) {
+			panic(fmt.Sprintf("min weight perfect matching of %v %v: expect %v, got %v", ends, ws, wantW, w))
+		}
+	}
+	fmt.Println("=======> test weighted matching with float weights pass")
+}
+
+// Large integer weights which differ by 1 must be compared exactly.
+func TestWeightedMatchingLargeWeights(t *testing.T) {
+	tri := NewGraph[int, int64](false, "")
+	for i := 1; i <= 3; i++ {
+		_ = tri.AddVertex(Vertex[int, int64]{Key: i})
+	}
+	_ = tri.AddEdge(Edge[int, int64]{Key: 0, Tail: 1, Head: 2, Weight: 1<<34 + 1})
+	_ = tri.AddEdge(Edge[int, int64]{Key: 1, Tail: 1, Head: 3, Weight: 1 << 34})
+	_ = tri.AddEdge(Edge[int, int64]{Key: 2, Tail: 2, Head: 3, Weight: 1<<34 + 2})
+	m, err := MaxWeightMatching(tri, false)
+	if err != nil {
+		panic(err)
+	}
+	if len(m) != 1 || m[0].Key != 2 {
+		panic(fmt.Sprintf("max weight matching should be edge 2,but get %v", m))
+	}
+
+	r := rand.New(rand.NewSource(52))
+	for round := 0; round < 500; round++ {
+		n := 2 + r.Intn(7)
+		g := NewGraph[int, int64](false, "")
+		for i := 0; i < n; i++ {
+			_ = g.AddVertex(Vertex[int, int64]{Key: i})
+		}
+		var (
+			ends [][2]int
+			ws   []int64
+		)
+		for k := r.Intn(3 * n); k > 0; k-- {
+			e, w := [2]int{r.Intn(n), r.Intn(n)}, int64(1)<<(33+r.Intn(8))+int64(r.Intn(4))
+			if err := g.AddEdge(Edge[int, int64]{Key: len(ends), Tail: e[0], Head: e[1], Weight: w}); err != nil {
+				panic(err)
+			}
+			ends, ws = append(ends, e), append(ws, w)
+		}
+		sum := func(m []Edge[int, int64]) (int, int64) {
+			var w int64
+			for _, e := range m {
+				w += e.Weight
+			}
+			return len(m), w
+		}
+		for _, maxCardinality := range []bool{false, true} {
+			m, err := MaxWeightMatching(g, maxCardinality)
+			if err != nil {
+				panic(err)
+			}
+			c, w := sum(m)
+			wantC, wantW := bruteForceWeightedMatching(n, ends, ws, maxCardinality, false)
+			if w != wantW || (maxCardinality && c != wantC) {
+				panic(fmt.Sprintf("max weight matching of %v %v (max cardinality %v): expect %d/%d, got %d/%d",
+					ends, ws, maxCardinality, wantC, wantW, c, w))
+			}
+		}
+		m, err := MinWeightPerfectMatching(g)
+		wantC, wantW := bruteForceWeightedMatching(n, ends, ws, false, true)
+		if wantC < 0 {
+			if err != errMatchNotExists {
+				panic(fmt.Sprintf("%v has no perfect matching, got %v", ends, m))
+			}
+			continue
+		}
+		if err != nil {
+			panic(fmt.Sprintf("%v: %v", ends, err))
+		}
+		if c, w := sum(m); c != wantC || w != wantW {
+			panic(fmt.Sprintf("min weight perfect matching of %v %v: expect %d, got %d", ends, ws, wantW, w))
+		}
+	}
+	fmt.Println("=======> test weighted matching with large weights pass")
+}
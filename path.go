@@ -73,9 +73,11 @@ func ShortestPaths[K comparable, W number](g Graph[K, W], source K) ([]Path[K, W
 	return shortestPathDijkstraWithPQ(g, source, source, true)
 }
 
-// get edge from v1 to v2(or v2 to v1) with the minimum weight.
+// Get the edge (arc v1->v2 for digraph) with minimum weight between v1 and v2,
+// the returned edge is oriented from v1 to v2, i.e. its tail is v1 and its head is v2.
 func getMinWeightEdge[K comparable, W number](g Graph[K, W], v1, v2 K) (*Edge[K, W], W, error) {
-	es, err := g.GetEdge(v1, v2)
+	// GetEdge(head, tail) returns arcs tail->head for digraph.
+	es, err := g.GetEdge(v2, v1)
 	if err != nil {
 		if !IsNotExists(err) {
 			return nil, 0, err
@@ -84,7 +86,9 @@ func getMinWeightEdge[K comparable, W number](g Graph[K, W], v1, v2 K) (*Edge[K,
 	var edge *Edge[K, W]
 	var n W
 	w := getMaxValue(n)
-	for _, e := range es {
+	for i := range es {
+		// take the address of a copy, the loop variable is shared between iterations.
+		e := es[i]
 		if g.IsDigraph() {
 			if e.Tail == v1 && e.Head == v2 {
 				if e.Weight < w {
@@ -95,8 +99,8 @@ func getMinWeightEdge[K comparable, W number](g Graph[K, W], v1, v2 K) (*Edge[K,
 			continue
 		}
 		if e.Weight < w {
-			e.Head = v1
-			e.Tail = v2
+			e.Head = v2
+			e.Tail = v1
 			w = e.Weight
 			edge = &e
 		}
@@ -18,6 +18,7 @@ package graphlib
 
 import (
 	"fmt"
+	"sort"
 	"testing"
 )
 
@@ -70,3 +71,44 @@ func TestPath1(t *testing.T) {
 	}
 
 }
+
+func TestShortestPathsEdges(t *testing.T) {
+	check := func(g Graph[int, int], target int, edges []int, weight int) {
+		ps, err := ShortestPaths(g, 0)
+		if err != nil {
+			panic(err.Error())
+		}
+		for _, p := range ps {
+			if p.Target != target {
+				continue
+			}
+			es := append([]int(nil), p.Edges...)
+			sort.Ints(es)
+			if p.Weight != weight || fmt.Sprint(es) != fmt.Sprint(edges) {
+				panic(fmt.Sprintf("shortest path to %d should be %v (weight %d),but get %v (weight %d)", target, edges, weight, p.Edges, p.Weight))
+			}
+			return
+		}
+		panic(fmt.Sprintf("not found shortest path to %d", target))
+	}
+	// parallel edges, the lighter one comes first.
+	g := NewGraph[int, int](false, "")
+	for i := 0; i < 3; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	_ = g.AddEdge(Edge[int, int]{Key: 10, Tail: 0, Head: 1, Weight: 1})
+	_ = g.AddEdge(Edge[int, int]{Key: 11, Tail: 0, Head: 1, Weight: 5})
+	_ = g.AddEdge(Edge[int, int]{Key: 12, Tail: 2, Head: 1, Weight: 1})
+	check(g, 2, []int{10, 12}, 2)
+
+	// arcs must be followed in their direction.
+	dg := NewGraph[int, int](true, "")
+	for i := 0; i < 3; i++ {
+		_ = dg.AddVertex(Vertex[int, int]{Key: i})
+	}
+	_ = dg.AddEdge(Edge[int, int]{Key: 10, Tail: 0, Head: 1, Weight: 1})
+	_ = dg.AddEdge(Edge[int, int]{Key: 11, Tail: 1, Head: 2, Weight: 1})
+	_ = dg.AddEdge(Edge[int, int]{Key: 12, Tail: 2, Head: 0, Weight: 1})
+	check(dg, 2, []int{10, 11}, 2)
+	fmt.Println("=======> test shortest paths edges pass")
+}
@@ -29,14 +29,45 @@ func CheckPlanarity[K comparable, W number](g Graph[K, W]) bool {
 	return CheckPlanarityLR(g)
 }
 
-// Boyer-Myrvold algorithm check if the given graph g is a planar graph.(This algorithm has not yet been implemented)
-func CheckPlanarityBM[K comparable, W number](g Graph[K, W]) bool { // TODO
-	panic("not implement now")
+// Boyer-Myrvold algorithm check if the given graph g is a planar graph.
+func CheckPlanarityBM[K comparable, W number](g Graph[K, W]) bool {
+	if g == nil {
+		return false
+	}
+	adj, ok := planarSimpleGraph(g)
+	if !ok {
+		return false
+	}
+	return newPlanarTestBM(adj).planarity()
 }
 
-// Hopcroft-Tarjan algorithm check if the given graph g is a planar graph.(This algorithm has not yet been implemented)
-func CheckPlanarityHT[K comparable, W number](g Graph[K, W]) bool { // TODO
-	panic("not implement now")
+// Hopcroft-Tarjan algorithm check if the given graph g is a planar graph.
+func CheckPlanarityHT[K comparable, W number](g Graph[K, W]) bool {
+	if g == nil {
+		return false
+	}
+	adj, ok := planarSimpleGraph(g)
+	if !ok {
+		return false
+	}
+	n := len(adj)
+	ig := newIndexedGraph(planarGraph(adj))
+	for _, b := range ig.lowpoint().blocks {
+		if len(b) < 3 {
+			continue
+		}
+		// the adjacency lists of the block.
+		badj := make([][]int, n)
+		for _, e := range b {
+			v, w := ig.edges[e].Head, ig.edges[e].Tail
+			badj[v] = append(badj[v], w)
+			badj[w] = append(badj[w], v)
+		}
+		if !newPlanarTestHT(badj).planarity(ig.edges[b[0]].Head) {
+			return false
+		}
+	}
+	return true
 }
 
 // Left-Right algorithm check if the given graph g is a planar graph.
@@ -44,10 +75,220 @@ func CheckPlanarityLR[K comparable, W number](g Graph[K, W]) bool {
 	if g == nil {
 		return false
 	}
-	p := &planarTestLR[K, W]{g: g}
+	adj, ok := planarSimpleGraph(g)
+	if !ok {
+		return false
+	}
+	p := &planarTestLR[int, int]{g: planarGraph(adj)}
 	return p.planarity(false)
 }
 
+// PlanarMap is a combinatorial embedding of a planar graph, i.e. the cyclic order of edges around every vertex.
+// A loop appears twice around its vertex, and every connected component is embedded separately.
+type PlanarMap[K comparable] struct {
+	// The edges (key) around every vertex in clockwise order.
+	Rotation map[K][]K
+	// The boundary walk of every face, an edge appears twice in the walk of a face if both sides of it lie on the face
+	// (for example, a bridge). By Euler's formula a connected component with n vertexes and m edges has m-n+2 faces,
+	// the outer faces of different components are not merged, and isolated vertexes have no face.
+	Faces [][]K
+}
+
+// Calculate a planar embedding of graph g by the Left-Right algorithm, the direction of arcs is ignored.
+// If g is not planar, a Kuratowski subgraph (a subdivision of K5 or K3,3) of g is returned with an error,
+// which is obtained by removing edges as long as the graph remains non-planar.
+func PlanarEmbedding[K comparable, W number](g Graph[K, W]) (*PlanarMap[K], Graph[K, W], error) {
+	if g == nil {
+		return nil, nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	adj := ig.simpleNeighbours()
+	p := &planarTestLR[int, int]{g: planarGraph(adj)}
+	if !p.planarity(true) {
+		k, err := kuratowskiSubgraph(g, ig, adj)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, k, errNotPlanar
+	}
+	return planarMap(ig, p.rotation()), nil, nil
+}
+
+// planarMap expands the rotation system of the simple graph to all the edges of the graph and traces the faces.
+// Parallel edges are placed next to each other, in reverse order at the two ends, and the two ends of a loop are adjacent.
+func planarMap[K comparable, W number](ig *indexedGraph[K, W], rot map[int][]int) *PlanarMap[K] {
+	n := ig.order()
+	// darts of edge e are 2e (from the tail) and 2e+1 (from the head).
+	parallel := make([]map[int][]int, n)
+	for i := range parallel {
+		parallel[i] = make(map[int][]int)
+	}
+	loops := make([][]int, n)
+	for e, ed := range ig.edges {
+		t, h := ig.idx[ed.Tail], ig.idx[ed.Head]
+		if t == h {
+			loops[t] = append(loops[t], 2*e, 2*e+1)
+			continue
+		}
+		parallel[t][h] = append(parallel[t][h], 2*e)
+		parallel[h][t] = append(parallel[h][t], 2*e+1)
+	}
+	darts := make([][]int, n)
+	pos := make([]int, 2*len(ig.edges))
+	for v := 0; v < n; v++ {
+		for _, w := range rot[v] {
+			ds := parallel[v][w]
+			if v < w {
+				darts[v] = append(darts[v], ds...)
+			} else {
+				for i := len(ds) - 1; i >= 0; i-- {
+					darts[v] = append(darts[v], ds[i])
+				}
+			}
+		}
+		darts[v] = append(darts[v], loops[v]...)
+		for i, d := range darts[v] {
+			pos[d] = i
+		}
+	}
+	res := &PlanarMap[K]{Rotation: make(map[K][]K, n)}
+	for v := 0; v < n; v++ {
+		es := make([]K, len(darts[v]))
+		for i, d := range darts[v] {
+			es[i] = ig.edges[d/2].Key
+		}
+		res.Rotation[ig.keys[v]] = es
+	}
+	// the face on the left of dart d continues with the dart after the reverse of d around its end.
+	end := func(d int) int {
+		if d%2 == 0 {
+			return ig.idx[ig.edges[d/2].Head]
+		}
+		return ig.idx[ig.edges[d/2].Tail]
+	}
+	visited := make([]bool, len(pos))
+	for v := 0; v < n; v++ {
+		for _, d := range darts[v] {
+			if visited[d] {
+				continue
+			}
+			var face []K
+			for !visited[d] {
+				visited[d] = true
+				face = append(face, ig.edges[d/2].Key)
+				w := end(d)
+				d = darts[w][(pos[d^1]+1)%len(darts[w])]
+			}
+			res.Faces = append(res.Faces, face)
+		}
+	}
+	return res
+}
+
+/*
+Find a Kuratowski subgraph of a non-planar graph.
+First find the shortest non-planar prefix of the edge list by binary search, which has at most 3n-5 edges,
+then try to remove the edges one by one, an edge is kept if the graph becomes planar without it.
+The remaining graph is minimal non-planar, so it is a subdivision of K5 or K3,3 (plus isolated vertexes which are dropped).
+*/
+func kuratowskiSubgraph[K comparable, W number](g Graph[K, W], ig *indexedGraph[K, W], adj [][]int) (Graph[K, W], error) {
+	n := len(adj)
+	var pairs [][2]int
+	for v, ns := range adj {
+		for _, w := range ns {
+			if v < w {
+				pairs = append(pairs, [2]int{v, w})
+			}
+		}
+	}
+	planar := func(ps [][2]int, skip int) bool {
+		m := len(ps)
+		if skip >= 0 {
+			m--
+		}
+		if m > 3*n-6 {
+			return false
+		}
+		sub := make([][]int, n)
+		for i, e := range ps {
+			if i != skip {
+				sub[e[0]] = append(sub[e[0]], e[1])
+				sub[e[1]] = append(sub[e[1]], e[0])
+			}
+		}
+		return newPlanarTestBM(sub).planarity()
+	}
+	lo, hi := 0, len(pairs)
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if planar(pairs[:mid], -1) {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	pairs = pairs[:lo]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		if !planar(pairs, i) {
+			pairs = append(pairs[:i], pairs[i+1:]...)
+		}
+	}
+	k := NewGraph[K, W](g.IsDigraph(), g.Name()+"_kuratowski")
+	added := make([]bool, n)
+	edge := make(map[[2]int]int)
+	for i := len(ig.edges) - 1; i >= 0; i-- {
+		t, h := ig.idx[ig.edges[i].Tail], ig.idx[ig.edges[i].Head]
+		edge[[2]int{min(t, h), max(t, h)}] = i
+	}
+	for _, p := range pairs {
+		for _, v := range p {
+			if !added[v] {
+				added[v] = true
+				vt, err := g.GetVertex(ig.keys[v])
+				if err != nil {
+					return nil, err
+				}
+				if err := k.AddVertex(vt.Clone()); err != nil {
+					return nil, err
+				}
+			}
+		}
+		if err := k.AddEdge(ig.edges[edge[p]].Clone()); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// planarSimpleGraph returns the adjacency lists of the underlying simple undirected graph of g,
+// i.e. the direction of arcs, loops and multiple edges are ignored since they do not affect planarity.
+// The second result is false if the graph has too many edges to be planar.
+func planarSimpleGraph[K comparable, W number](g Graph[K, W]) ([][]int, bool) {
+	adj := newIndexedGraph(g).simpleNeighbours()
+	n, m := len(adj), 0
+	for _, ns := range adj {
+		m += len(ns)
+	}
+	m /= 2
+	return adj, n < 3 || m <= 3*n-6
+}
+
+// planarGraph builds the simple graph of the adjacency lists, vertex v has key v and edges are keyed by their index.
+func planarGraph(adj [][]int) Graph[int, int] {
+	g := NewGraph[int, int](false, "")
+	for v := range adj {
+		g.AddVertex(Vertex[int, int]{Key: v})
+	}
+	for v, ns := range adj {
+		for _, w := range ns {
+			if v < w {
+				g.AddEdge(Edge[int, int]{Key: g.Size(), Head: v, Tail: w})
+			}
+		}
+	}
+	return g
+}
+
 type interval struct {
 	low  int
 	high int
@@ -77,10 +318,11 @@ type planarTestLR[K comparable, W number] struct {
 	lowptEdge    []int
 	ref          []int
 	side         []int
-	leftRef      []int
+	leftRef      []int // the leftmost (vertex) neighbour of v embedded by now, for embedding phase.
 	rightRef     []int
 	oriented     [][2]int      // [e][0] -> [e][1]
 	orderedAdj   map[int][]int // key:vertex index,val: ordered slice of edges
+	emb          *rotationSystem
 
 	top         int
 	S           stack[*pair]
@@ -92,11 +334,6 @@ func (p *planarTestLR[K, W]) orient(e int, tail, head int) {
 	p.orderedAdj[tail] = append(p.orderedAdj[tail], e)
 }
 
-func (p *planarTestLR[K, W]) getEdge(u, v int) int {
-	es, _ := p.g.GetEdge(p.vtx[u].Key, p.vtx[v].Key)
-	return p.edgeIdx[es[0].Key]
-}
-
 func (p *planarTestLR[K, W]) init(embedding bool) {
 	p.vtx = p.g.AllVertexes()
 	p.edges = p.g.AllEdges()
@@ -135,15 +372,20 @@ func (p *planarTestLR[K, W]) init(embedding bool) {
 	//
 	if embedding {
 		p.leftRef, p.rightRef = make([]int, n), make([]int, n)
+		p.emb = newRotationSystem(n)
 	}
 }
 
 func (p *planarTestLR[K, W]) dfsOrient(v int) {
 	e := p.parentEdge[v]
-	nbs, _ := p.g.Neighbours(p.vtx[v].Key)
-	for _, n := range nbs {
-		w := p.vtxIdx[n.Key]
-		vw := p.getEdge(v, w)
+	vk := p.vtx[v].Key
+	es, _ := p.g.IncidentEdges(vk)
+	for _, ed := range es {
+		w := p.vtxIdx[ed.Head]
+		if ed.Head == vk {
+			w = p.vtxIdx[ed.Tail]
+		}
+		vw := p.edgeIdx[ed.Key]
 		if p.oriented[vw] == [2]int{0, 0} { // there exists some non-oriented {v,w} ∈ E
 			// orient v -> w
 			p.orient(vw, v, w)
@@ -339,7 +581,7 @@ func (p *planarTestLR[K, W]) planarity(embedding bool) bool {
 	n, m := p.g.Order(), p.g.Size()
 	if n > 2 && m > 3*n-6 {
 		return false
-	} else if n < 5 {
+	} else if n < 5 && !embedding {
 		return true
 	}
 	p.init(embedding)
@@ -371,6 +613,18 @@ func (p *planarTestLR[K, W]) planarity(embedding bool) bool {
 	}
 	// sort adjacency lists according to non-decreasing nesting_depth
 	p.sortEdges()
+	for v := 0; v < n; v++ {
+		prev := -1
+		for _, e := range p.getOutEdges(v) {
+			w := p.target(e)
+			if prev == -1 {
+				p.emb.addFirst(v, w)
+			} else {
+				p.emb.addAfter(v, w, prev)
+			}
+			prev = w
+		}
+	}
 	for _, r := range p.roots {
 		p.dfsEmbedding(r)
 	}
@@ -390,96 +644,190 @@ func (p *planarTestLR[K, W]) dfsEmbedding(v int) {
 	for _, ei := range p.getOutEdges(v) {
 		w := p.target(ei)
 		if ei == p.parentEdge[w] {
-			// TODO make ei first edge in adjacency list of w
-
-			p.leftRef[v], p.rightRef[v] = ei, ei
-			p.dfsEmbedding(v)
+			// make ei first edge in adjacency list of w
+			p.emb.addFirst(w, v)
+			p.leftRef[v], p.rightRef[v] = w, w
+			p.dfsEmbedding(w)
+		} else if p.side[ei] == 1 {
+			// place ei directly after rightRef[w] in adjacency list of w
+			p.emb.addAfter(w, v, p.rightRef[w])
 		} else {
-			if p.side[ei] == 1 {
-				// TODO place ei directly after rightRef[w] in adjacency list of w
-			} else {
-				// TODO place ei directly before leftRef[w] in adjacency list of w
-				p.leftRef[w] = ei
+			// place ei directly before leftRef[w] in adjacency list of w
+			p.emb.addBefore(w, v, p.leftRef[w])
+			p.leftRef[w] = v
+		}
+	}
+}
+
+// rotation returns the neighbours of every vertex (by key) in clockwise order, it should be called after the embedding phase.
+func (p *planarTestLR[K, W]) rotation() map[K][]K {
+	res := make(map[K][]K, len(p.vtx))
+	for v := range p.vtx {
+		ns := p.emb.neighbours(v)
+		ks := make([]K, len(ns))
+		for i, w := range ns {
+			ks[i] = p.vtx[w].Key
+		}
+		res[p.vtx[v].Key] = ks
+	}
+	return res
+}
+
+// rotationSystem is a combinatorial embedding of a simple graph,
+// the neighbours of every vertex form a cyclic doubly linked list in clockwise order.
+type rotationSystem struct {
+	cw    []map[int]int
+	ccw   []map[int]int
+	first []int
+}
+
+func newRotationSystem(n int) *rotationSystem {
+	r := &rotationSystem{
+		cw:    make([]map[int]int, n),
+		ccw:   make([]map[int]int, n),
+		first: make([]int, n),
+	}
+	for i := 0; i < n; i++ {
+		r.cw[i], r.ccw[i], r.first[i] = make(map[int]int), make(map[int]int), -1
+	}
+	return r
+}
+
+// addFirst inserts w into the neighbours of v as the first one.
+func (r *rotationSystem) addFirst(v, w int) {
+	if r.first[v] == -1 {
+		r.cw[v][w], r.ccw[v][w], r.first[v] = w, w, w
+		return
+	}
+	r.addAfter(v, w, r.ccw[v][r.first[v]])
+	r.first[v] = w
+}
+
+// addAfter inserts w into the neighbours of v directly after ref in clockwise order.
+func (r *rotationSystem) addAfter(v, w, ref int) {
+	next := r.cw[v][ref]
+	r.cw[v][ref], r.ccw[v][w] = w, ref
+	r.cw[v][w], r.ccw[v][next] = next, w
+}
+
+// addBefore inserts w into the neighbours of v directly before ref in clockwise order.
+func (r *rotationSystem) addBefore(v, w, ref int) {
+	r.addAfter(v, w, r.ccw[v][ref])
+}
+
+func (r *rotationSystem) has(v, w int) bool {
+	_, ok := r.cw[v][w]
+	return ok
+}
+
+// neighbours returns the neighbours of v in clockwise order.
+func (r *rotationSystem) neighbours(v int) []int {
+	var ns []int
+	if w := r.first[v]; w != -1 {
+		for {
+			ns = append(ns, w)
+			if w = r.cw[v][w]; w == r.first[v] {
+				break
 			}
 		}
 	}
+	return ns
 }
 
-type planarTestHT[K comparable, W number] struct {
-	g       Graph[K, W]
-	vtx     []Vertex[K, W]
-	edges   []Edge[K, W]
-	vtxIdx  map[K]int // key: v.Key, value: index of vtx
-	edgeIdx map[K]int // key: e.Key, value: index of edges
+// nextFaceEdge returns the half edge following (v,w) on the boundary of their face.
+func (r *rotationSystem) nextFaceEdge(v, w int) (int, int) {
+	return w, r.ccw[w][v]
+}
 
-	num    int
-	roots  []int // root vertex index of each connected component.
-	number []int // vertex number (root vertex number is 1).
+/*
+Hopcroft-Tarjan algorithm, in the formulation of Mehlhorn and Mutzel.
+
+The graph is divided into biconnected components, and every component is tested separately.
+A dfs turns the component into a palm tree: tree arcs lead from a vertex to its children and fronds lead from a vertex to an ancestor.
+The out edges of every vertex are ordered by cost, where the cost of a frond (v,w) is 2*number[w], and the cost of a tree arc (v,w) is
+2*lowpt1[w], plus 1 if lowpt2[w] < number[v]. Following first edges from any tree arc gives a path which ends with a frond to
+the lowest possible vertex.
+
+For a tree arc e0=(x,y), the path of first edges from y together with the tree path from x forms a cycle c, and the rest of the
+subtree of y is divided into segments, every segment starts from a vertex of c by a frond or a tree arc.
+Let Att(S) be the attachments of segment S, i.e. the numbers of the vertexes of c (except the start of S) which are reached by fronds of S.
+The segment e0 is strongly planar if it has a planar embedding where all the attachments to ancestors of x lie on the same face,
+this holds iff every segment of c is strongly planar and the segments can be placed on the two sides of c without interlacing,
+where the segments which attach above w0 (the end of the cycle) must be placed on the same side.
+
+The segments are processed bottom-up along c, a block is a maximal set of segments whose placement determines each other,
+Latt/Ratt are the attachments on the left and right side of the block, in decreasing order.
+The graph is planar iff the tree arc from the root (which is the only child of the root in a biconnected component) is strongly planar.
+*/
+type planarTestHT struct {
+	adj    [][]int
+	number []int // dfs number of vertexes, -1 means not visited.
+	parent []int
 	lowpt1 []int
 	lowpt2 []int
+	out    [][]int // out neighbours (children and ancestors reached by fronds) of vertexes, ordered by cost.
+}
 
-	oriented   [][3]int         // [e]{tail,head,frond}
-	orderedAdj map[int][][2]int // key:vertex index(tail),value: {e,head}
+type planarBlockHT struct {
+	latt []int
+	ratt []int
+}
 
-	s     int   // a global variable,record the start vertex's number of the current path, and is initialized to 0.
-	pathN int   // path number
-	path  []int // path number of vertexes
+func (b *planarBlockHT) flip() { b.latt, b.ratt = b.ratt, b.latt }
 
-	L    []int
-	R    []int
-	B    [][2]int
-	FREE int
+// leftInterlace checks if b interlaces with the left side of the top block.
+func (b *planarBlockHT) leftInterlace(top *planarBlockHT) bool {
+	return top != nil && len(top.latt) > 0 && b.latt[len(b.latt)-1] < top.latt[0]
 }
 
-func (p *planarTestHT[K, W]) getEdge(u, v int) int {
-	es, _ := p.g.GetEdge(p.vtx[u].Key, p.vtx[v].Key)
-	return p.edgeIdx[es[0].Key]
+// rightInterlace checks if b interlaces with the right side of the top block.
+func (b *planarBlockHT) rightInterlace(top *planarBlockHT) bool {
+	return top != nil && len(top.ratt) > 0 && b.latt[len(b.latt)-1] < top.ratt[0]
 }
 
-func (p *planarTestHT[K, W]) init() {
-	p.vtx = p.g.AllVertexes()
-	p.edges = p.g.AllEdges()
-	p.vtxIdx = make(map[K]int)
-	p.edgeIdx = make(map[K]int)
-	for i, v := range p.vtx {
-		p.vtxIdx[v.Key] = i
+func (b *planarBlockHT) combine(b1 *planarBlockHT) {
+	b.latt = append(b.latt, b1.latt...)
+	b.ratt = append(b.ratt, b1.ratt...)
+}
+
+// clean removes all the attachments to w, and reports whether the block is empty.
+func (b *planarBlockHT) clean(w int) bool {
+	for len(b.latt) > 0 && b.latt[0] == w {
+		b.latt = b.latt[1:]
 	}
-	for i, e := range p.edges {
-		p.edgeIdx[e.Key] = i
+	for len(b.ratt) > 0 && b.ratt[0] == w {
+		b.ratt = b.ratt[1:]
 	}
-	m, n := p.g.Size(), p.g.Order()
-	p.number = make([]int, n)
-	p.lowpt1 = make([]int, n)
-	p.lowpt2 = make([]int, n)
-	p.oriented = make([][3]int, m)
+	return len(b.latt) == 0 && len(b.ratt) == 0
 }
 
-func (p *planarTestHT[K, W]) orient(e int, tail, head int, frond int) {
-	p.oriented[e] = [3]int{tail, head, frond}
+func newPlanarTestHT(adj [][]int) *planarTestHT {
+	n := len(adj)
+	p := &planarTestHT{
+		adj:    adj,
+		number: make([]int, n),
+		parent: make([]int, n),
+		lowpt1: make([]int, n),
+		lowpt2: make([]int, n),
+		out:    make([][]int, n),
+	}
+	for i := range p.number {
+		p.number[i] = -1
+		p.parent[i] = -1
+	}
+	return p
 }
 
-func (p *planarTestHT[K, W]) dfs(u, v int) { // u is father node of v
-	p.number[v] = p.num
-	p.num++
-
+// number vertexes and transform the component into a palm tree.
+func (p *planarTestHT) dfs(v int, num *int) {
+	p.number[v] = *num
+	*num++
 	p.lowpt1[v], p.lowpt2[v] = p.number[v], p.number[v]
-
-	vk := p.vtx[v].Key
-	es, err := p.g.IncidentEdges(vk)
-	if err != nil {
-		return
-	}
-	for _, e := range es {
-		var w int
-		if e.Head == vk {
-			w = p.vtxIdx[e.Tail]
-		} else {
-			w = p.vtxIdx[e.Head]
-		}
-		vw := p.edgeIdx[e.Key]
-		if p.number[w] == 0 {
-			p.orient(vw, v, w, 0) // orient v -> w
-			p.dfs(v, w)
+	for _, w := range p.adj[v] {
+		if p.number[w] == -1 {
+			p.parent[w] = v
+			p.out[v] = append(p.out[v], w)
+			p.dfs(w, num)
 			if p.lowpt1[w] < p.lowpt1[v] {
 				p.lowpt2[v] = min(p.lowpt1[v], p.lowpt2[w])
 				p.lowpt1[v] = p.lowpt1[w]
@@ -488,313 +836,463 @@ func (p *planarTestHT[K, W]) dfs(u, v int) { // u is father node of v
 			} else {
 				p.lowpt2[v] = min(p.lowpt2[v], p.lowpt1[w])
 			}
-		} else {
-			if p.number[w] < p.number[v] && w != u {
-				// mark (v,w) as a frond
-				p.orient(vw, v, w, 1)
-				if p.number[w] < p.lowpt1[v] {
-					p.lowpt2[v] = p.lowpt1[v]
-					p.lowpt1[v] = p.number[w]
-				} else if p.number[w] > p.lowpt1[v] {
-					p.lowpt2[v] = min(p.lowpt2[v], p.number[w])
-				}
+		} else if p.number[w] < p.number[v] && w != p.parent[v] {
+			// (v,w) is a frond
+			p.out[v] = append(p.out[v], w)
+			if p.number[w] < p.lowpt1[v] {
+				p.lowpt2[v] = p.lowpt1[v]
+				p.lowpt1[v] = p.number[w]
+			} else if p.number[w] > p.lowpt1[v] {
+				p.lowpt2[v] = min(p.lowpt2[v], p.number[w])
 			}
 		}
 	}
 }
 
-func (p *planarTestHT[K, W]) sortEdges() {
-	n, m := len(p.vtx), len(p.edges)
-	bucket := make([][]int, 2*n+2)
-	p.orderedAdj = make(map[int][][2]int)
-	var v, w, f int
-	for e := 0; e < m; e++ {
-		v, w, f = p.oriented[e][0], p.oriented[e][1], p.oriented[e][2]
-		if f == 1 {
-			bucket[2*p.number[w]] = append(bucket[2*p.number[w]], e)
-		} else {
-			if p.lowpt2[w] >= p.number[v] {
-				bucket[2*p.lowpt1[w]] = append(bucket[2*p.lowpt1[w]], e)
+func (p *planarTestHT) isTreeArc(v, w int) bool {
+	return p.parent[w] == v
+}
+
+// sort out edges by cost with bucket sort.
+func (p *planarTestHT) sortEdges() {
+	n := len(p.adj)
+	bucket := make([][][2]int, 2*n+2)
+	for v, ws := range p.out {
+		for _, w := range ws {
+			var c int
+			if !p.isTreeArc(v, w) {
+				c = 2 * p.number[w]
+			} else if p.lowpt2[w] >= p.number[v] {
+				c = 2 * p.lowpt1[w]
 			} else {
-				bucket[2*p.lowpt1[w]+1] = append(bucket[2*p.lowpt1[w]+1], e)
+				c = 2*p.lowpt1[w] + 1
 			}
+			bucket[c] = append(bucket[c], [2]int{v, w})
+		}
+		p.out[v] = p.out[v][:0]
+	}
+	for _, b := range bucket {
+		for _, e := range b {
+			p.out[e[0]] = append(p.out[e[0]], e[1])
 		}
 	}
-	for i := 1; i < 2*n+2; i++ {
-		if bucket[i] != nil {
-			for _, e := range bucket[i] {
-				v, w = p.oriented[e][0], p.oriented[e][1]
-				p.orderedAdj[v] = append(p.orderedAdj[v], [2]int{e, w})
+}
+
+// stronglyPlanar checks if the segment of tree arc (x,y) is strongly planar,
+// and returns the attachments of the segment in decreasing order.
+func (p *planarTestHT) stronglyPlanar(x, y int) ([]int, bool) {
+	// follow first edges to the frond which ends the cycle.
+	wk := y
+	for p.isTreeArc(wk, p.out[wk][0]) {
+		wk = p.out[wk][0]
+	}
+	w0 := p.out[wk][0]
+	var blocks []*planarBlockHT
+	top := func() *planarBlockHT {
+		if len(blocks) == 0 {
+			return nil
+		}
+		return blocks[len(blocks)-1]
+	}
+	for w := wk; w != x; w = p.parent[w] {
+		for _, u := range p.out[w][1:] {
+			var att []int
+			if p.isTreeArc(w, u) {
+				a, ok := p.stronglyPlanar(w, u)
+				if !ok {
+					return nil, false
+				}
+				att = a
+			} else {
+				att = []int{p.number[u]}
 			}
+			b := &planarBlockHT{latt: att}
+			for {
+				if b.leftInterlace(top()) {
+					top().flip()
+				}
+				if b.leftInterlace(top()) {
+					return nil, false
+				}
+				if !b.rightInterlace(top()) {
+					break
+				}
+				b.combine(top())
+				blocks = blocks[:len(blocks)-1]
+			}
+			blocks = append(blocks, b)
+		}
+		for len(blocks) > 0 && top().clean(p.number[p.parent[w]]) {
+			blocks = blocks[:len(blocks)-1]
+		}
+	}
+	var att []int
+	n0 := p.number[w0]
+	for i := len(blocks) - 1; i >= 0; i-- {
+		b := blocks[i]
+		if len(b.latt) > 0 && len(b.ratt) > 0 && b.latt[0] > n0 && b.ratt[0] > n0 {
+			return nil, false
+		}
+		if len(b.ratt) > 0 && b.ratt[0] > n0 {
+			b.flip()
 		}
+		att = append(att, b.latt...)
+		att = append(att, b.ratt...)
 	}
+	if w0 != x {
+		att = append(att, n0)
+	}
+	return att, true
 }
 
-/*Stacks L and R are stored as linked lists using arrays STACK and NEXT.
-STACK (i) gives a stack entry, and NEXT(i) points to the next entry on the same stack.
-NEXT(0) points to the first entry on L. NEXT(-1) points to the first entry on R.
-FREE is the first unused location in STACK.
+// planarity tests a biconnected component with at least 3 vertexes, the vertexes not in the component have no neighbours.
+func (p *planarTestHT) planarity(root int) bool {
+	num := 0
+	p.dfs(root, &num)
+	p.sortEdges()
+	_, ok := p.stronglyPlanar(root, p.out[root][0])
+	return ok
+}
 
-Variable p denotes the number of the current path. If v is a vertex PATH(v) denotes the number of the first path containing
-v. If i is the number of a path, f(i) denotes the last vertex on the path numbered i.
+/*
+Boyer-Myrvold algorithm, i.e. the edge addition method.
 
-Blocks are represented as ordered pairs on stack B. If (x, y) is on B, x denotes the last entry on L in the block,
-and y denotes the last entry on R in the block. If x = 0 (y = 0), the block has no entries on L(R).
-SAVE is a temporary variable used for switching;
+Vertexes are numbered by dfs, and processed in reverse order. Every tree edge (parent(c),c) initially forms a biconnected component
+(bicomp) with a virtual copy of parent(c) as its root. When vertex v is processed, the back edges from descendants of v to v are
+embedded into the external face of the partial embedding, and the bicomps separated by vertexes on the way are merged.
 
-Let a block B be a maximal set of entries on L and
-R which correspond to fronds such that the placement of any one of the fronds determines the placement of all the others.
-The blocks change as the content of the stacks change, but the blocks always partition the stack entries.
-*/
+A vertex w is pertinent if it has a back edge to v or it is the root of a bicomp containing pertinent vertexes,
+and w is externally active if it or one of its separated children (whose bicomp has not been merged into w yet)
+has a back edge to an ancestor of v. Walkup marks the pertinent vertexes and bicomps for every back edge,
+then Walkdown traverses the external face of every child bicomp of v in both directions, embeds the back edges and merges bicomps,
+it never passes externally active vertexes (stopping vertexes), so that they stay on the external face.
+The graph is planar iff all the back edges are embedded.
 
-func (p *planarTestHT[K, W]) STACK(i int) int { // L
-	return -1
+The adjacency list of every vertex is a doubly linked list of arcs, the first and the last arc of a vertex on the external face are
+the edges of the external face, bicomps may have inconsistent orientation, so the way out of a vertex is decided by the arc coming in.
+*/
+type planarTestBM struct {
+	n             int
+	adj           [][]int // adjacency lists by dfs number.
+	parent        []int
+	children      [][]int
+	leastAncestor []int
+	lowpoint      []int
+	backEdges     [][]int // back edges to v from descendants.
+
+	// vertexes in [n,2n) are virtual roots, n+c is the root of the bicomp of tree edge (parent(c),c).
+	first [][2]int // the two ends of the arc list.
+	arcs  []planarArcBM
+
+	visited       []int
+	backedgeFlag  []int
+	rootsFront    [][]int // internally active pertinent roots, used as a stack.
+	rootsBack     [][]int // externally active pertinent roots, used as a queue.
+	separated     [][]int // separated children sorted by lowpoint.
+	separatedHead []int
+	merged        []bool
+	stack         []int
 }
 
-func (p *planarTestHT[K, W]) NEXT(i int) int { // R
-	return -1
+type planarArcBM struct {
+	to   int
+	link [2]int // previous and next arc in the list.
 }
 
-func (p *planarTestHT[K, W]) SETNEXT(i int, val int) {
+func newPlanarTestBM(adj [][]int) *planarTestBM {
+	n := len(adj)
+	p := &planarTestBM{n: n}
+	// dfs numbering.
+	number := make([]int, n)
+	for i := range number {
+		number[i] = -1
+	}
+	vs := make([]int, 0, n)
+	par := make([]int, n)
+	next := make([]int, n)
+	for s := 0; s < n; s++ {
+		if number[s] != -1 {
+			continue
+		}
+		number[s], par[s] = len(vs), -1
+		vs = append(vs, s)
+		st := []int{s}
+		for len(st) > 0 {
+			v := st[len(st)-1]
+			if next[v] == len(adj[v]) {
+				st = st[:len(st)-1]
+				continue
+			}
+			w := adj[v][next[v]]
+			next[v]++
+			if number[w] == -1 {
+				number[w], par[w] = len(vs), v
+				vs = append(vs, w)
+				st = append(st, w)
+			}
+		}
+	}
+	p.adj = make([][]int, n)
+	p.parent = make([]int, n)
+	p.children = make([][]int, n)
+	for i, v := range vs {
+		p.parent[i] = -1
+		if par[v] >= 0 {
+			p.parent[i] = number[par[v]]
+			p.children[p.parent[i]] = append(p.children[p.parent[i]], i)
+		}
+		for _, w := range adj[v] {
+			p.adj[i] = append(p.adj[i], number[w])
+		}
+	}
+	p.leastAncestor = make([]int, n)
+	p.lowpoint = make([]int, n)
+	p.backEdges = make([][]int, n)
+	for v := n - 1; v >= 0; v-- {
+		p.leastAncestor[v] = v
+		for _, u := range p.adj[v] {
+			if u < v && u != p.parent[v] {
+				p.leastAncestor[v] = min(p.leastAncestor[v], u)
+				p.backEdges[u] = append(p.backEdges[u], v)
+			}
+		}
+		p.lowpoint[v] = p.leastAncestor[v]
+		for _, c := range p.children[v] {
+			p.lowpoint[v] = min(p.lowpoint[v], p.lowpoint[c])
+		}
+	}
+	// separated children sorted by lowpoint with bucket sort.
+	bucket := make([][]int, n)
+	for v := 0; v < n; v++ {
+		bucket[p.lowpoint[v]] = append(bucket[p.lowpoint[v]], v)
+	}
+	p.separated = make([][]int, n)
+	for _, b := range bucket {
+		for _, c := range b {
+			if p.parent[c] >= 0 {
+				p.separated[p.parent[c]] = append(p.separated[p.parent[c]], c)
+			}
+		}
+	}
+	p.separatedHead = make([]int, n)
+	p.merged = make([]bool, n)
+	// every tree edge is a singleton bicomp.
+	p.first = make([][2]int, 2*n)
+	for i := range p.first {
+		p.first[i] = [2]int{-1, -1}
+	}
+	for c := 0; c < n; c++ {
+		if p.parent[c] >= 0 {
+			p.insertArc(n+c, c, 0)
+			p.insertArc(c, n+c, 0)
+		}
+	}
+	p.visited = make([]int, 2*n)
+	p.backedgeFlag = make([]int, n)
+	for i := range p.visited {
+		p.visited[i] = n
+	}
+	for i := range p.backedgeFlag {
+		p.backedgeFlag[i] = n
+	}
+	p.rootsFront = make([][]int, n)
+	p.rootsBack = make([][]int, n)
+	return p
 }
 
-func (p *planarTestHT[K, W]) SETSTACK(i int, val int) {
+// twin of arc a is a^1.
+func twinArc(a int) int { return a ^ 1 }
+
+// insertArc adds an arc from x to y at the end s of the list of x.
+func (p *planarTestBM) insertArc(x, y, s int) int {
+	a := len(p.arcs)
+	p.arcs = append(p.arcs, planarArcBM{to: y, link: [2]int{-1, -1}})
+	if old := p.first[x][s]; old == -1 {
+		p.first[x] = [2]int{a, a}
+	} else {
+		p.arcs[a].link[1^s] = old
+		p.arcs[old].link[s] = a
+		p.first[x][s] = a
+	}
+	return a
 }
 
-// If i is the number of a path, F(i) denotes the last vertex on the path numbered i.
-func (p *planarTestHT[K, W]) F(i int) int {
-	return -1
+// embedEdge adds the edge (x,y) at the end sx of the list of x and the end sy of the list of y.
+func (p *planarTestBM) embedEdge(x, sx, y, sy int) {
+	p.insertArc(x, y, sx)
+	p.insertArc(y, x, sy)
 }
 
-func (p *planarTestHT[K, W]) pathFinder(v int) bool {
-	vn := p.number[v]
-	for _, out := range p.orderedAdj[v] {
-		e, w := out[0], out[1]
-		if p.oriented[e][2] == 0 { // tree edge
-			if p.s == 0 { // start a new path
-				p.s = vn
-				p.pathN++ // pn is a path number,we use it to trace a path.
-			}
-			// add (v,w) to current path.
-			p.path[w] = p.pathN
-			if !p.pathFinder(w) {
-				return false
-			}
-			// delete stack entries and blocks corresponding to vertices no smaller than v;
-			for _, e1 := range p.B {
-				xn, yn := e1[0], e1[1]
-				if (p.STACK(xn) >= vn || xn == 0) && (p.STACK(yn) >= vn || yn == 0) {
-					// TODO: delete (x, y) from B;
-				}
-			}
-			var xn, yn int
-			// TODO: if (xn, yn) on B has STACK(xn) >= vn then replace (xn, yn) on B by (0, yn);
-			// TODO: if (xn, yn) on B has STACK(yn) >= vn then replace (xn, yn) on B by (xn, 0);
-			for p.NEXT(-1) != 0 && p.STACK(p.NEXT(-1)) >= vn {
-				p.SETNEXT(-1, p.NEXT(p.NEXT(-1)))
-			}
-			for p.NEXT(0) != 0 && p.NEXT(p.NEXT(0)) >= vn {
-				p.SETNEXT(0, p.NEXT(p.NEXT(0)))
-			}
-			if p.path[v] != p.path[w] {
-				// all of segment with first edge (v, w) has been embedded. New blocks must be moved from right to left;
-				L := 0
-				for _, e1 := range p.B {
-					xn, yn := e1[0], e1[1]
-					if p.STACK(xn) > p.F(p.path[w]) || p.STACK(yn) > p.F(p.path[w]) && p.STACK(p.NEXT(-1)) != 0 {
-						if p.STACK(xn) > p.F(p.path[w]) {
-							if p.STACK(yn) > p.F(p.path[w]) {
-								return false
-							}
-							L = xn
-						} else {
-							SAVE := p.NEXT(L)
-							p.SETNEXT(L, p.NEXT(-1))
-							p.SETNEXT(-1, p.NEXT(yn))
-							p.SETNEXT(yn, SAVE)
-							L = yn
-						}
-						// TODO: delete (x, y) from B;
-					}
-				}
-				// block on B must be combined with new blocks just deleted;
-				// TODO: delete (xn, yn) from B;
-				if xn != 0 {
-					// TODO: add (xn,yn) to B;
-				} else if L != 0 || yn != 0 {
-					// TODO: add (L,yn) to B;
-				}
-				// delete end-of-stack marker on right stack;
-				p.SETNEXT(-1, p.NEXT(p.NEXT(-1)))
-			}
+// extFaceNext leaves x from the side opposite to prevLink, and returns the next vertex on the external face
+// and the side from which it is entered.
+func (p *planarTestBM) extFaceNext(x, prevLink int) (int, int) {
+	a := p.first[x][1^prevLink]
+	w := p.arcs[a].to
+	if p.first[w][0] != p.first[w][1] {
+		if p.first[w][0] == twinArc(a) {
+			prevLink = 0
 		} else {
-			// v --> w. Current path is complete.
-			if p.s == 0 {
-				p.pathN++
-				p.s = p.number[v]
-			}
-			// TODO: f(p) := w;
-			L, R := 0, -1
-			for (p.NEXT(L) != 0 && p.STACK(p.NEXT(L)) > p.number[w]) || (p.NEXT(R) != 0 && p.STACK(p.NEXT(R)) > p.number[w]) {
-				var xn, yn int //TODO: (x,y) on B
-				if xn != 0 && yn != 0 {
-					if p.STACK(p.NEXT(L)) > p.number[w] {
-						if p.STACK(p.NEXT(R)) > p.number[w] {
-							return false
-						}
-						SAVE := p.NEXT(R)
-						p.SETNEXT(R, p.NEXT(L))
-						p.SETNEXT(L, SAVE)
-						//
-						SAVE = p.NEXT(xn)
-						p.SETNEXT(xn, p.NEXT(yn))
-						p.SETNEXT(yn, SAVE)
-						L, R = yn, xn
-					} else {
-						L, R = xn, yn
-					}
-				} else if xn != 0 {
-					save := p.NEXT(xn)
-					p.SETNEXT(xn, p.NEXT(R))
-					p.SETNEXT(R, p.NEXT(L))
-					p.SETNEXT(L, save)
-					R = xn
-				} else {
-					R = yn
-				}
-				// TODO: delete (x, y) from B;
-			}
-			// add P to left stack if p is normal;
-			if p.F(p.s) < p.number[w] {
-				if L == 0 {
-					L = p.FREE
-				}
-				// TODO: STACK(FREE) := f;  ---> p.SETSTACK(p.FREE) = f
-				p.SETNEXT(p.FREE, p.NEXT(0))
-				p.SETNEXT(0, p.FREE)
-				p.FREE++
-			}
-			if R == -1 {
-				R = 0
-			}
-			if L != 0 || R != 0 || vn != p.s {
-				// TODO: add (L,R) to B
-			}
-			if vn != p.s {
-				p.SETSTACK(p.FREE, 0)
-				p.SETNEXT(p.FREE, p.NEXT(-1))
-				p.SETNEXT(-1, p.FREE)
-				p.FREE++
-			}
-			// TODO: add (v,w) to current path
-			// TODO: output current path
-			p.s = 0
+			prevLink = 1
 		}
 	}
-	return true
+	return w, prevLink
 }
 
-/*
-When c is removed, G falls into several connected pieces, called segments. Each segment
-S consists either of a single frond (v, w), or of a tree arc (v, w) plus a subtree with root w plus all fronds leading from the subtree.
+// activeNext is like extFaceNext but skips the inactive vertexes, i.e. vertexes which are neither pertinent nor externally active.
+func (p *planarTestBM) activeNext(x, prevLink, v int) (int, int) {
+	w, wPrev := p.extFaceNext(x, prevLink)
+	for w != x && !p.pertinent(w, v) && !p.externallyActive(w, v) {
+		w, wPrev = p.extFaceNext(w, wPrev)
+	}
+	return w, wPrev
+}
 
-A segment must be embedded completely on one side of c by the Jordan Curve Theorem.
-*/
+func (p *planarTestBM) pertinent(w, v int) bool {
+	return p.backedgeFlag[w] == v || len(p.rootsFront[w]) > 0 || len(p.rootsBack[w]) > 0
+}
 
-/*
-We use Lemma 9 to test planarity, in the following way:
-first we embed the cycle c in the plane.
-Then we embed the segments one at a time in the order they are explored during pathfinding.
-To embed a segment S, we find a path in it, say p. We choose a side, say the left, on which to embed p.
-We compare p with previously embedded fronds to determine if p can be embedded.
-If not, we move segments which have fronds blocking p from the left to the right.
-If p can be embedded after moving segments, we embed it.
-However, if we move segments from the left to the right we may have to move other segments from the right to the left.
-Thus it may be impossible to embed p. If so, we declare the graph nonplanar.
-
-If p can be embedded, we try to embed the rest of S by in essence using the algorithm recursively.
-Then we try to embed the next segment.
-*/
+func (p *planarTestBM) externallyActive(w, v int) bool {
+	if p.leastAncestor[w] < v {
+		return true
+	}
+	s := p.separated[w]
+	for p.separatedHead[w] < len(s) && p.merged[s[p.separatedHead[w]]] {
+		p.separatedHead[w]++
+	}
+	return p.separatedHead[w] < len(s) && p.lowpoint[s[p.separatedHead[w]]] < v
+}
 
-/*
-EMBED()
-began
-
-	L := R := B := the empty stack;
-	find first cycle c;
-	while (some segment is unexplored) do
-		begin
-			initiate search for path in next segment S;
-			when backing down tree arc v -> w delete entries on L and R and blocks on B containing vertices no smaller than v;
-			let p: s-->*f be first path found in segment S;
-			while (position of top block determines position of p) do
-				begin
-					delete top block from B;
-					if (block entries on left) then
-						switch block of entries from L to R and from R to L by switching list pointers;
-					if (block still has an entry on left in conflict with p) then
-						go to nonplanar;
-				end;
-			if (p is normal) then
-				add last vertex of p to L;
-			add new block to B corresponding to p and blocks just removed from B;
-			add end-of-stack marker to R;
-			call embedding algorithm recursively;
-			for each (new block (x, y) on B) do
-				begin
-					if (x != 0) and (y != 0) then
-						go to nonplanar;
-					if (y != 0) then
-						move entries in block to L;
-					delete (x, y) from B;
-				end;
-			delete end-of-stack marker on R;
-			add one block to B to represent S minus path p;
-			combine top two blocks on B;
-		end;
-
-end;
-*/
-func (p *planarTestHT[K, W]) embed(r int) bool {
-	// integer array STACK(0 :: E), NEXT(-1 :: E), f(1 :: E - V + 1), PATH(1 :: V); B(1 :: E);
-	m := len(p.edges)
+func (p *planarTestBM) internallyActive(w, v int) bool {
+	return p.pertinent(w, v) && !p.externallyActive(w, v)
+}
 
-	p.L, p.R = make([]int, m), make([]int, m)
-	p.B = make([][2]int, m)
+func (p *planarTestBM) firstPertinentRoot(w int) int {
+	if k := len(p.rootsFront[w]); k > 0 {
+		return p.rootsFront[w][k-1]
+	}
+	return p.rootsBack[w][0]
+}
 
-	p.SETNEXT(-1, 0)
-	p.SETNEXT(0, 0)
-	p.FREE = 1
-	p.SETSTACK(0, 0)
-	p.pathN = 0
-	p.s = 0
-	p.path[r] = 1
-	return p.pathFinder(r)
+// walkup marks the vertexes and bicomps on the way from w to v as pertinent.
+func (p *planarTestBM) walkup(v, w int) {
+	n := p.n
+	p.backedgeFlag[w] = v
+	x, xPrev, y, yPrev := w, 1, w, 0
+	for x != v {
+		if p.visited[x] == v || p.visited[y] == v {
+			break
+		}
+		p.visited[x], p.visited[y] = v, v
+		r := -1
+		if x >= n {
+			r = x
+		} else if y >= n {
+			r = y
+		}
+		if r == -1 {
+			x, xPrev = p.extFaceNext(x, xPrev)
+			y, yPrev = p.extFaceNext(y, yPrev)
+			continue
+		}
+		c := r - n
+		z := p.parent[c]
+		if z != v {
+			if p.lowpoint[c] < v {
+				p.rootsBack[z] = append(p.rootsBack[z], r)
+			} else {
+				p.rootsFront[z] = append(p.rootsFront[z], r)
+			}
+		}
+		x, xPrev, y, yPrev = z, 1, z, 0
+	}
 }
 
-func (p *planarTestHT[K, W]) planarity() bool {
-	n, m := p.g.Order(), p.g.Size()
-	if n > 2 && m > 3*n-6 {
-		return false
-	} else if n < 5 {
-		return true
+// mergeBicomp merges the bicomp with root r into z, where z is entered from zPrevLink and r is left from rout.
+func (p *planarTestBM) mergeBicomp(z, zPrevLink, r, rout int) {
+	if zPrevLink == rout {
+		// invert the root so that the external face stays consistent.
+		for a := p.first[r][0]; a != -1; a = p.arcs[a].link[0] {
+			p.arcs[a].link[0], p.arcs[a].link[1] = p.arcs[a].link[1], p.arcs[a].link[0]
+		}
+		p.first[r][0], p.first[r][1] = p.first[r][1], p.first[r][0]
+	}
+	for a := p.first[r][0]; a != -1; a = p.arcs[a].link[1] {
+		p.arcs[twinArc(a)].to = z
+	}
+	s := zPrevLink
+	if p.first[z][s] == -1 {
+		p.first[z] = p.first[r]
+	} else {
+		ez, er := p.first[z][s], p.first[r][1^s]
+		p.arcs[ez].link[s] = er
+		p.arcs[er].link[1^s] = ez
+		p.first[z][s] = p.first[r][s]
 	}
-	p.init()
-	// TODO divide G into biconnected components;
+	p.first[r] = [2]int{-1, -1}
+	if k := len(p.rootsFront[z]); k > 0 {
+		p.rootsFront[z] = p.rootsFront[z][:k-1]
+	} else {
+		p.rootsBack[z] = p.rootsBack[z][1:]
+	}
+	p.merged[r-p.n] = true
+}
 
-	// 1.number vertices and transform G into a palm trees;
-	for s := 0; s < n; s++ {
-		if p.number[s] == 0 {
-			p.roots = append(p.roots, s)
-			p.dfs(0, s)
+// walkdown embeds the back edges to v from the bicomp with root r.
+func (p *planarTestBM) walkdown(v, r int) {
+	p.stack = p.stack[:0]
+	for e := 0; e < 2; e++ {
+		w, wPrev := p.extFaceNext(r, 1^e)
+		for w != r {
+			if p.backedgeFlag[w] == v {
+				for len(p.stack) > 0 {
+					k := len(p.stack)
+					r1, rout, z, zPrev := p.stack[k-2], p.stack[k-1], p.stack[k-4], p.stack[k-3]
+					p.stack = p.stack[:k-4]
+					p.mergeBicomp(z, zPrev, r1, rout)
+				}
+				p.embedEdge(r, e, w, wPrev)
+				p.backedgeFlag[w] = p.n
+			}
+			if len(p.rootsFront[w]) > 0 || len(p.rootsBack[w]) > 0 {
+				p.stack = append(p.stack, w, wPrev)
+				r1 := p.firstPertinentRoot(w)
+				x, xPrev := p.activeNext(r1, 1, v)
+				y, yPrev := p.activeNext(r1, 0, v)
+				var rout int
+				if p.internallyActive(x, v) {
+					w, wPrev, rout = x, xPrev, 0
+				} else if p.internallyActive(y, v) {
+					w, wPrev, rout = y, yPrev, 1
+				} else if p.pertinent(x, v) {
+					w, wPrev, rout = x, xPrev, 0
+				} else {
+					w, wPrev, rout = y, yPrev, 1
+				}
+				p.stack = append(p.stack, r1, rout)
+			} else if !p.pertinent(w, v) && !p.externallyActive(w, v) {
+				w, wPrev = p.extFaceNext(w, wPrev)
+			} else {
+				break
+			}
+		}
+		if len(p.stack) > 0 {
+			return
 		}
 	}
-	// 2.
-	p.sortEdges()
-	p.path = make([]int, n)
-	for _, r := range p.roots {
-		if !p.embed(r) {
-			return false
+}
+
+func (p *planarTestBM) planarity() bool {
+	for v := p.n - 1; v >= 0; v-- {
+		for _, w := range p.backEdges[v] {
+			p.walkup(v, w)
+		}
+		for _, c := range p.children[v] {
+			p.walkdown(v, p.n+c)
+		}
+		for _, w := range p.backEdges[v] {
+			if p.backedgeFlag[w] == v {
+				return false
+			}
 		}
 	}
 	return true
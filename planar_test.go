@@ -19,6 +19,7 @@ package graphlib
 import (
 	"flag"
 	"fmt"
+	"math/rand"
 	"testing"
 )
 
@@ -50,3 +51,321 @@ func TestPlanar(t *testing.T) {
 	default:
 	}
 }
+
+var planarityCheckers = []struct {
+	name  string
+	check func(Graph[int, int]) bool
+}{
+	{"LR", CheckPlanarityLR[int, int]},
+	{"BM", CheckPlanarityBM[int, int]},
+	{"HT", CheckPlanarityHT[int, int]},
+}
+
+func checkAllPlanarity(g Graph[int, int], want bool, info string) {
+	for _, c := range planarityCheckers {
+		if res := c.check(g); res != want {
+			panic(fmt.Sprintf("%s check %s: expect %v, get %v", c.name, info, want, res))
+		}
+	}
+}
+
+// bruteForcePlanar enumerates all the rotation systems of a simple graph, the graph is planar iff some rotation system
+// has n-m+f = 1+c faces by Euler's formula (isolated vertexes are not counted).
+func bruteForcePlanar(adj [][]int) bool {
+	n, m, nv, c := len(adj), 0, 0, 0
+	comp := make([]bool, n)
+	for s := 0; s < n; s++ {
+		m += len(adj[s])
+		if comp[s] || len(adj[s]) == 0 {
+			continue
+		}
+		c++
+		stk := []int{s}
+		comp[s] = true
+		for len(stk) > 0 {
+			x := stk[len(stk)-1]
+			stk = stk[:len(stk)-1]
+			nv++
+			for _, y := range adj[x] {
+				if !comp[y] {
+					comp[y] = true
+					stk = append(stk, y)
+				}
+			}
+		}
+	}
+	m /= 2
+	want := m - nv + 2*c
+	rot := make([][]int, n)
+	pos := make([]map[int]int, n)
+	for v := range adj {
+		rot[v] = append([]int{}, adj[v]...)
+		pos[v] = make(map[int]int)
+	}
+	faces := func() int {
+		for v := range rot {
+			for i, u := range rot[v] {
+				pos[v][u] = i
+			}
+		}
+		seen := make(map[[2]int]bool)
+		f := 0
+		for v := range rot {
+			for _, u := range rot[v] {
+				if seen[[2]int{v, u}] {
+					continue
+				}
+				f++
+				for a, b := v, u; !seen[[2]int{a, b}]; {
+					seen[[2]int{a, b}] = true
+					a, b = b, rot[b][(pos[b][a]+1)%len(rot[b])]
+				}
+			}
+		}
+		return f
+	}
+	// the first neighbour of every vertex is fixed, permute the others.
+	var perm func(v, k int) bool
+	perm = func(v, k int) bool {
+		if v == n {
+			return faces() == want
+		}
+		if k >= len(rot[v]) {
+			return perm(v+1, 1)
+		}
+		for i := k; i < len(rot[v]); i++ {
+			rot[v][k], rot[v][i] = rot[v][i], rot[v][k]
+			if perm(v, k+1) {
+				return true
+			}
+			rot[v][k], rot[v][i] = rot[v][i], rot[v][k]
+		}
+		return false
+	}
+	return perm(0, 1)
+}
+
+// testGridGraph returns a r*c grid with random diagonals, which is planar.
+func testGridGraph(rd *rand.Rand, r, c int) [][3]int {
+	var es [][3]int
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := i*c + j
+			if j+1 < c {
+				es = append(es, [3]int{v, v + 1, 1})
+			}
+			if i+1 < r {
+				es = append(es, [3]int{v, v + c, 1})
+			}
+			if i+1 < r && j+1 < c {
+				if rd.Intn(2) == 0 {
+					es = append(es, [3]int{v, v + c + 1, 1})
+				} else {
+					es = append(es, [3]int{v + 1, v + c, 1})
+				}
+			}
+		}
+	}
+	return es
+}
+
+func TestPlanarityCrossCheck(t *testing.T) {
+	known := []Graph[int, int]{
+		PetersenGraph(),
+		CompleteGraph(4),
+		CompleteGraph(5),
+		CompleteBipartite(2, 3),
+		CompleteBipartite(3, 3),
+		Hypercube(3),
+		Hypercube(4),
+	}
+	expect := []bool{false, true, false, true, false, true, false}
+	for i, g := range known {
+		checkAllPlanarity(g, expect[i], g.Name())
+	}
+	// multiple edges, loops and arcs do not affect planarity.
+	var es [][3]int
+	for i := 0; i < 4; i++ {
+		es = append(es, [3]int{i, i, 1})
+		for j := 0; j < 4; j++ {
+			if i != j {
+				es = append(es, [3]int{i, j, 1}, [3]int{j, i, 1})
+			}
+		}
+	}
+	checkAllPlanarity(testMultigraph(false, 4, es), true, "K4 multigraph")
+	checkAllPlanarity(testMultigraph(true, 4, es), true, "K4 digraph")
+	es = es[:0]
+	for i := 0; i < 5; i++ {
+		for j := 0; j < 5; j++ {
+			if i != j {
+				es = append(es, [3]int{i, j, 1})
+			}
+		}
+	}
+	checkAllPlanarity(testMultigraph(true, 5, es), false, "K5 digraph")
+	// a subdivision of K3,3 in a disconnected graph.
+	es = [][3]int{{0, 6, 1}, {6, 3, 1}, {0, 4, 1}, {0, 5, 1}, {1, 3, 1}, {1, 7, 1}, {7, 4, 1}, {1, 5, 1}, {2, 3, 1}, {2, 4, 1}, {2, 5, 1}, {8, 9, 1}}
+	checkAllPlanarity(testMultigraph(false, 10, es), false, "K3,3 subdivision")
+
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 300; round++ {
+		n := 5 + r.Intn(2)
+		g := testRandomGraph(r, n, 0.3+0.5*r.Float64())
+		adj := newIndexedGraph(g).simpleNeighbours()
+		rotations := 1
+		for _, ns := range adj {
+			for k := 2; k < len(ns); k++ {
+				rotations *= k
+			}
+		}
+		if rotations > 20000 {
+			continue
+		}
+		want := bruteForcePlanar(adj)
+		checkAllPlanarity(g, want, fmt.Sprintf("random graph %v", g.AllEdges()))
+	}
+	for round := 0; round < 500; round++ {
+		g := testRandomGraph(r, 6+r.Intn(30), 0.05+0.25*r.Float64())
+		checkAllPlanarity(g, CheckPlanarityLR(g), fmt.Sprintf("random graph %v", g.AllEdges()))
+	}
+	for round := 0; round < 50; round++ {
+		rows, cols := 2+r.Intn(15), 2+r.Intn(15)
+		es := testGridGraph(r, rows, cols)
+		checkAllPlanarity(testMultigraph(false, rows*cols, es), true, fmt.Sprintf("grid %dx%d", rows, cols))
+		for k := 0; k < 3; k++ {
+			es = append(es, [3]int{r.Intn(rows * cols), r.Intn(rows * cols), 1})
+		}
+		g := testMultigraph(false, rows*cols, es)
+		checkAllPlanarity(g, CheckPlanarityLR(g), fmt.Sprintf("grid %dx%d with random edges", rows, cols))
+	}
+	fmt.Println("=======> test planarity cross check pass")
+}
+
+func checkPlanarMap(g Graph[int, int], pm *PlanarMap[int]) {
+	count := make(map[int]int)
+	for v, es := range pm.Rotation {
+		for _, e := range es {
+			ed, err := g.GetEdgeByKey(e)
+			if err != nil || (ed.Head != v && ed.Tail != v) {
+				panic(fmt.Sprintf("edge %d is not incident to %d", e, v))
+			}
+			count[e]++
+		}
+	}
+	for _, e := range g.AllEdges() {
+		if count[e.Key] != 2 {
+			panic(fmt.Sprintf("edge %d appears %d times in rotation", e.Key, count[e.Key]))
+		}
+		count[e.Key] = 0
+	}
+	for _, f := range pm.Faces {
+		for _, e := range f {
+			count[e]++
+		}
+	}
+	for _, e := range g.AllEdges() {
+		if count[e.Key] != 2 {
+			panic(fmt.Sprintf("edge %d appears %d times in faces", e.Key, count[e.Key]))
+		}
+	}
+	// Euler's formula for every connected component.
+	root := make(map[int]int)
+	var find func(v int) int
+	find = func(v int) int {
+		if r, ok := root[v]; ok && r != v {
+			root[v] = find(r)
+			return root[v]
+		}
+		return v
+	}
+	n, c := 0, 0
+	for _, es := range pm.Rotation {
+		if len(es) > 0 {
+			n++
+		}
+	}
+	for _, e := range g.AllEdges() {
+		if a, b := find(e.Head), find(e.Tail); a != b {
+			root[a] = b
+			c--
+		}
+	}
+	c += n
+	if len(pm.Faces) != g.Size()-n+2*c {
+		panic(fmt.Sprintf("expect %d faces, get %d", g.Size()-n+2*c, len(pm.Faces)))
+	}
+}
+
+func checkKuratowski(k Graph[int, int]) {
+	if CheckPlanarity(k) {
+		panic(fmt.Sprintf("kuratowski subgraph %v is planar", k.AllEdges()))
+	}
+	deg := make(map[int]int)
+	for _, e := range k.AllEdges() {
+		deg[e.Head]++
+		deg[e.Tail]++
+	}
+	branch := make(map[int]int)
+	for _, d := range deg {
+		branch[d]++
+	}
+	if !(branch[4] == 5 && branch[4]+branch[2] == len(deg)) && !(branch[3] == 6 && branch[3]+branch[2] == len(deg)) {
+		panic(fmt.Sprintf("kuratowski subgraph %v has degrees %v", k.AllEdges(), branch))
+	}
+	for _, e := range k.AllEdges() {
+		h, _ := k.Clone()
+		h.RemoveEdgeByKey(e.Key)
+		if !CheckPlanarity(h) {
+			panic(fmt.Sprintf("kuratowski subgraph %v is not minimal", k.AllEdges()))
+		}
+	}
+}
+
+func TestPlanarEmbedding(t *testing.T) {
+	var es [][3]int
+	for i := 0; i < 4; i++ {
+		es = append(es, [3]int{i, i, 1})
+		for j := 0; j < 4; j++ {
+			if i != j {
+				es = append(es, [3]int{i, j, 1}, [3]int{j, i, 1})
+			}
+		}
+	}
+	graphs := []Graph[int, int]{
+		CompleteGraph(4),
+		CompleteBipartite(2, 3),
+		Hypercube(3),
+		testMultigraph(false, 4, es),
+		testMultigraph(true, 4, es),
+		testMultigraph(false, 3, [][3]int{{0, 1, 1}}),
+	}
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 20; round++ {
+		rows, cols := 2+r.Intn(10), 2+r.Intn(10)
+		graphs = append(graphs, testMultigraph(false, rows*cols, testGridGraph(r, rows, cols)))
+	}
+	for round := 0; round < 500; round++ {
+		graphs = append(graphs, testRandomGraph(r, 3+r.Intn(15), 0.1+0.4*r.Float64()))
+	}
+	graphs = append(graphs, PetersenGraph(), CompleteGraph(5), CompleteBipartite(3, 3), Hypercube(4), CompleteGraph(8))
+	planar, nonPlanar := 0, 0
+	for _, g := range graphs {
+		pm, k, err := PlanarEmbedding(g)
+		if CheckPlanarity(g) {
+			if err != nil || k != nil {
+				panic(fmt.Sprintf("graph %v should be planar", g.AllEdges()))
+			}
+			checkPlanarMap(g, pm)
+			planar++
+		} else {
+			if err == nil || pm != nil {
+				panic(fmt.Sprintf("graph %v should not be planar", g.AllEdges()))
+			}
+			checkKuratowski(k)
+			nonPlanar++
+		}
+	}
+	fmt.Printf("check %d planar graphs and %d non-planar graphs\n", planar, nonPlanar)
+	fmt.Println("=======> test planar embedding pass")
+}
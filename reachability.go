@@ -0,0 +1,337 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// bitset is a set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+func (b bitset) add(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+func (b bitset) union(o bitset) {
+	for i := range b {
+		b[i] |= o[i]
+	}
+}
+
+// call f for every element in increasing order.
+func (b bitset) each(f func(int)) {
+	for i, w := range b {
+		for w != 0 {
+			j := bits.TrailingZeros64(w)
+			f(i*64 + j)
+			w &= w - 1
+		}
+	}
+}
+
+// condensation is the DAG of strongly connected components of a digraph,
+// comp[v] is the component of vertex v and components are numbered in topological order,
+// succ[c] records the successors of component c in increasing order,
+// and reach[c] records the components reachable from c (including c itself).
+type condensation struct {
+	comp       []int
+	components [][]int
+	succ       [][]int
+	reach      []bitset
+}
+
+func newCondensation[K comparable, W number](g Digraph[K, W], ig *indexedGraph[K, W]) (*condensation, error) {
+	cs, _, err := StronglyConnectedComponent(g, false)
+	if err != nil {
+		return nil, err
+	}
+	n := ig.order()
+	comp := make([]int, n)
+	for i, c := range cs {
+		for _, v := range c {
+			comp[ig.idx[v]] = i
+		}
+	}
+	// topological sort of components (Kahn).
+	m := len(cs)
+	succ := make([]map[int]bool, m)
+	indeg := make([]int, m)
+	for i := range succ {
+		succ[i] = make(map[int]bool)
+	}
+	for _, e := range ig.edges {
+		t, h := comp[ig.idx[e.Tail]], comp[ig.idx[e.Head]]
+		if t != h && !succ[t][h] {
+			succ[t][h] = true
+			indeg[h]++
+		}
+	}
+	order := make([]int, 0, m)
+	for i := 0; i < m; i++ {
+		if indeg[i] == 0 {
+			order = append(order, i)
+		}
+	}
+	for i := 0; i < len(order); i++ {
+		for j := range succ[order[i]] {
+			if indeg[j]--; indeg[j] == 0 {
+				order = append(order, j)
+			}
+		}
+	}
+	rank := make([]int, m)
+	for i, c := range order {
+		rank[c] = i
+	}
+	cd := &condensation{
+		comp:       make([]int, n),
+		components: make([][]int, m),
+		succ:       make([][]int, m),
+		reach:      make([]bitset, m),
+	}
+	for v := 0; v < n; v++ {
+		c := rank[comp[v]]
+		cd.comp[v] = c
+		cd.components[c] = append(cd.components[c], v)
+	}
+	for c, s := range succ {
+		for d := range s {
+			cd.succ[rank[c]] = append(cd.succ[rank[c]], rank[d])
+		}
+	}
+	for c := m - 1; c >= 0; c-- {
+		sort.Ints(cd.succ[c])
+		cd.reach[c] = newBitset(m)
+		cd.reach[c].add(c)
+		for _, d := range cd.succ[c] {
+			cd.reach[c].union(cd.reach[d])
+		}
+	}
+	return cd, nil
+}
+
+// Find the arcs of a transitive reduction of the condensation:
+// process the successors of c in topological order, and keep the arc (c,d) only if d is not reachable from the kept ones.
+func (cd *condensation) reduction() [][]int {
+	res := make([][]int, len(cd.succ))
+	for c, ss := range cd.succ {
+		covered := newBitset(len(cd.succ))
+		for _, d := range ss {
+			if !covered.has(d) {
+				res[c] = append(res[c], d)
+				covered.union(cd.reach[d])
+			}
+		}
+	}
+	return res
+}
+
+func copyVertexes[K comparable, W number](g Graph[K, W], h Graph[K, W]) error {
+	for _, v := range g.AllVertexes() {
+		if err := h.AddVertex(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Add arc tail->head to h, reuse the arc of g if exists, otherwise create a new arc whose key is generated by newEdgeKey.
+// If newEdgeKey is nil a random key is used.
+func addDerivedArc[K comparable, W number](h Graph[K, W], arcs map[[2]int]Edge[K, W], ig *indexedGraph[K, W], tail, head int, newEdgeKey func(Edge[K, W]) K) error {
+	if e, ok := arcs[[2]int{tail, head}]; ok {
+		return h.AddEdge(e)
+	}
+	e := Edge[K, W]{Tail: ig.keys[tail], Head: ig.keys[head]}
+	if newEdgeKey != nil {
+		e.Key = newEdgeKey(e)
+		return h.AddEdge(e)
+	}
+	var err error
+	for i := 0; i < 50; i++ {
+		e.Key = edgeFormat(e.Tail, e.Head)
+		if err = h.AddEdge(e); err == nil || !IsAlreadyExists(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func prepareDerivedDigraph[K comparable, W number](g Digraph[K, W], name string) (*indexedGraph[K, W], *condensation, map[[2]int]Edge[K, W], Digraph[K, W], error) {
+	if g == nil {
+		return nil, nil, nil, nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, nil, nil, nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	cd, err := newCondensation(g, ig)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	// the first arc between every pair of vertexes.
+	arcs := make(map[[2]int]Edge[K, W])
+	for _, e := range ig.edges {
+		p := [2]int{ig.idx[e.Tail], ig.idx[e.Head]}
+		if _, ok := arcs[p]; !ok {
+			arcs[p] = e
+		}
+	}
+	h := NewDigraph[K, W](g.Name() + name)
+	if err := copyVertexes[K, W](g, h); err != nil {
+		return nil, nil, nil, nil, err
+	}
+	return ig, cd, arcs, h, nil
+}
+
+/*
+Calculate the transitive closure of digraph g, i.e. a new digraph with the same vertexes
+which has an arc (u,v) for every pair of distinct vertexes u and v such that v is reachable from u. Loops are not added.
+
+Existing arcs of g are kept (if there are parallel arcs, only one of them), and the keys of new arcs are generated by newEdgeKey,
+if newEdgeKey is nil, random keys are used.
+The closure is computed on the condensation of g, so it also works for cyclic digraphs.
+*/
+func TransitiveClosure[K comparable, W number](g Digraph[K, W], newEdgeKey func(Edge[K, W]) K) (Digraph[K, W], error) {
+	ig, cd, arcs, h, err := prepareDerivedDigraph(g, "_closure")
+	if err != nil {
+		return nil, err
+	}
+	for u := 0; u < ig.order(); u++ {
+		cd.reach[cd.comp[u]].each(func(c int) {
+			for _, v := range cd.components[c] {
+				if err == nil && v != u {
+					err = addDerivedArc(h, arcs, ig, u, v, newEdgeKey)
+				}
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+/*
+Calculate the transitive reduction of digraph g, i.e. a new digraph with the same vertexes and the same reachability relation
+which has as few arcs as possible. Loops and parallel arcs are removed.
+
+If g is a DAG, the transitive reduction is unique and it's a subgraph of g.
+Otherwise, the vertexes of every strongly connected component are joined by a directed cycle,
+and two components are joined by an arc if there is an arc between them in the transitive reduction of the condensation.
+Existing arcs of g are reused if possible, and the keys of new arcs are generated by newEdgeKey,
+if newEdgeKey is nil, random keys are used.
+*/
+func TransitiveReduction[K comparable, W number](g Digraph[K, W], newEdgeKey func(Edge[K, W]) K) (Digraph[K, W], error) {
+	ig, cd, arcs, h, err := prepareDerivedDigraph(g, "_reduction")
+	if err != nil {
+		return nil, err
+	}
+	for _, vs := range cd.components {
+		if len(vs) < 2 {
+			continue
+		}
+		for i, v := range vs {
+			if err := addDerivedArc(h, arcs, ig, v, vs[(i+1)%len(vs)], newEdgeKey); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// join two components by an existing arc between them.
+	between := make(map[[2]int]Edge[K, W])
+	for _, e := range ig.edges {
+		p := [2]int{cd.comp[ig.idx[e.Tail]], cd.comp[ig.idx[e.Head]]}
+		if _, ok := between[p]; !ok && p[0] != p[1] {
+			between[p] = e
+		}
+	}
+	for c, ds := range cd.reduction() {
+		for _, d := range ds {
+			if err := h.AddEdge(between[[2]int{c, d}]); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h, nil
+}
+
+// ReachabilityIndex answers whether a vertex is reachable from another in O(1) time.
+// Every vertex is mapped to its strongly connected component, and every component records
+// the set of reachable components as a bitset, so the index takes O(n + c^2/64) words for c components.
+// The index is a snapshot of the digraph, it does not reflect later modification of the digraph.
+type ReachabilityIndex[K comparable] struct {
+	comp  map[K]int
+	reach []bitset
+}
+
+// Build the reachability index of digraph g.
+func NewReachabilityIndex[K comparable, W number](g Digraph[K, W]) (*ReachabilityIndex[K], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if !g.IsDigraph() {
+		return nil, errNotDigraph
+	}
+	ig := newIndexedGraph[K, W](g)
+	cd, err := newCondensation(g, ig)
+	if err != nil {
+		return nil, err
+	}
+	r := &ReachabilityIndex[K]{
+		comp:  make(map[K]int),
+		reach: cd.reach,
+	}
+	for i, k := range ig.keys {
+		r.comp[k] = cd.comp[i]
+	}
+	return r, nil
+}
+
+// Check if there is a directed path from u to v, every vertex is reachable from itself.
+func (r *ReachabilityIndex[K]) Reachable(u, v K) (bool, error) {
+	cu, ok := r.comp[u]
+	if !ok {
+		return false, errVertexNotExists
+	}
+	cv, ok := r.comp[v]
+	if !ok {
+		return false, errVertexNotExists
+	}
+	return r.reach[cu].has(cv), nil
+}
+
+// Check if u and v are in the same strongly connected component, i.e. they are reachable from each other.
+func (r *ReachabilityIndex[K]) StronglyConnected(u, v K) (bool, error) {
+	cu, ok := r.comp[u]
+	if !ok {
+		return false, errVertexNotExists
+	}
+	cv, ok := r.comp[v]
+	if !ok {
+		return false, errVertexNotExists
+	}
+	return cu == cv, nil
+}
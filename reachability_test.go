@@ -0,0 +1,104 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testDigraph(n int, arcs [][2]int) Digraph[int, int] {
+	g := NewDigraph[int, int]("")
+	for i := 0; i < n; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, a := range arcs {
+		if err := g.AddEdge(Edge[int, int]{Key: i, Tail: a[0], Head: a[1]}); err != nil {
+			panic(err.Error())
+		}
+	}
+	return g
+}
+
+func TestTransitiveReduction(t *testing.T) {
+	g := testDigraph(4, [][2]int{{0, 1}, {1, 2}, {0, 2}, {2, 3}, {0, 3}})
+	r, err := TransitiveReduction(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if r.Size() != 3 {
+		panic(fmt.Sprintf("reduction should have 3 arcs,but get %d", r.Size()))
+	}
+	for _, k := range []int{0, 1, 3} {
+		if _, err := r.GetEdgeByKey(k); err != nil {
+			panic(fmt.Sprintf("arc %d should be kept", k))
+		}
+	}
+	c, err := TransitiveClosure(g, nil)
+	if err != nil {
+		panic(err.Error())
+	}
+	if c.Size() != 6 {
+		panic(fmt.Sprintf("closure should have 6 arcs,but get %d", c.Size()))
+	}
+
+	// cyclic digraph.
+	key := 100
+	newKey := func(Edge[int, int]) int {
+		key++
+		return key
+	}
+	g = testDigraph(4, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}, {1, 0}})
+	if r, err = TransitiveReduction(g, newKey); err != nil {
+		panic(err.Error())
+	}
+	if r.Size() != 4 {
+		panic(fmt.Sprintf("reduction should have 4 arcs,but get %d", r.Size()))
+	}
+	if c, err = TransitiveClosure(g, newKey); err != nil {
+		panic(err.Error())
+	}
+	if c.Size() != 9 {
+		panic(fmt.Sprintf("closure should have 9 arcs,but get %d", c.Size()))
+	}
+}
+
+func TestReachabilityIndex(t *testing.T) {
+	g := testDigraph(5, [][2]int{{0, 1}, {1, 2}, {2, 0}, {2, 3}})
+	r, err := NewReachabilityIndex(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	cases := []struct {
+		u, v int
+		ok   bool
+	}{
+		{0, 3, true}, {3, 0, false}, {1, 0, true}, {4, 4, true}, {0, 4, false},
+	}
+	for _, c := range cases {
+		ok, err := r.Reachable(c.u, c.v)
+		if err != nil {
+			panic(err.Error())
+		}
+		if ok != c.ok {
+			panic(fmt.Sprintf("reachable(%d,%d) should be %v", c.u, c.v, c.ok))
+		}
+	}
+	if ok, _ := r.StronglyConnected(0, 2); !ok {
+		panic("0 and 2 are strongly connected")
+	}
+}
@@ -0,0 +1,424 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"sort"
+)
+
+// Sparse matrix in coordinate format, entry k is data[k] at (row[k],col[k]).
+// Duplicate entries are allowed and summed when the matrix is converted.
+type COOMatrix[K comparable, W number] struct {
+	rows, cols       int
+	rowKeys, colKeys []K
+	row, col         []int
+	data             []W
+}
+
+// Sparse matrix in compressed sparse row format, the column indexes and values of row i
+// are indices[indptr[i]:indptr[i+1]] and data[indptr[i]:indptr[i+1]], the column indexes are in ascending order.
+type CSRMatrix[K comparable, W number] struct {
+	rows, cols       int
+	rowKeys, colKeys []K
+	indptr           []int
+	indices          []int
+	data             []W
+}
+
+// The incidence matrix of a graph, the rows are vertexes and the columns are edges.
+// For undirected graph the entry of a vertex and an incident edge is 1 (2 for a loop),
+// for digraph the matrix is oriented: the entry is -1 for the tail and 1 for the head of an arc, loops are zero columns.
+type IncidenceMatrix[K comparable] struct {
+	oriented bool
+	*CSRMatrix[K, int]
+}
+
+func (m *IncidenceMatrix[K]) Oriented() bool {
+	return m.oriented
+}
+
+// Create a CSR matrix from a dense matrix, rows and cols are the keys of rows and columns and can be nil.
+func NewCSRMatrix[K comparable, W number](data [][]W, rows, cols []K) (*CSRMatrix[K, W], error) {
+	n, m := len(data), 0
+	if n > 0 {
+		m = len(data[0])
+	}
+	if (rows != nil && len(rows) != n) || (cols != nil && len(cols) != m) {
+		return nil, errShapeMismatch
+	}
+	sm := &CSRMatrix[K, W]{rows: n, cols: m, rowKeys: rows, colKeys: cols, indptr: make([]int, n+1)}
+	for i, r := range data {
+		if len(r) != m {
+			return nil, errShapeMismatch
+		}
+		for j, x := range r {
+			if x != 0 {
+				sm.indices = append(sm.indices, j)
+				sm.data = append(sm.data, x)
+			}
+		}
+		sm.indptr[i+1] = len(sm.indices)
+	}
+	return sm, nil
+}
+
+// Create the sparse adjacency matrix of graph, the entry (i,j) is the number of edges from vertex i to vertex j,
+// it is symmetric for undirected graph and a loop is counted once.
+func NewSparseAdjacencyMatrix[K comparable, W number](g Graph[K, W]) (*CSRMatrix[K, int], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	coo := newCOOMatrix[K, int](ig.keys, ig.keys)
+	for _, e := range ig.edges {
+		i, j := ig.idx[e.Tail], ig.idx[e.Head]
+		coo.add(i, j, 1)
+		if !ig.digraph && i != j {
+			coo.add(j, i, 1)
+		}
+	}
+	return coo.ToCSR(), nil
+}
+
+// Create the sparse weight matrix of a simple graph, the entry (i,j) is the weight of the edge from vertex i to vertex j.
+// Unlike WeightMatrix absent edges are not stored, so an edge with weight 0 is not distinguishable from no edge.
+func NewSparseWeightMatrix[K comparable, W number](g Graph[K, W]) (*CSRMatrix[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	p, err := g.Property(ProSimple)
+	if err != nil {
+		return nil, err
+	}
+	if !p.Value.(bool) {
+		return nil, errNotSimple
+	}
+	ig := newIndexedGraph(g)
+	coo := newCOOMatrix[K, W](ig.keys, ig.keys)
+	for _, e := range ig.edges {
+		i, j := ig.idx[e.Tail], ig.idx[e.Head]
+		coo.add(i, j, e.Weight)
+		if !ig.digraph {
+			coo.add(j, i, e.Weight)
+		}
+	}
+	return coo.ToCSR(), nil
+}
+
+// Create the incidence matrix of graph.
+func NewIncidenceMatrix[K comparable, W number](g Graph[K, W]) (*IncidenceMatrix[K], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	edges := make([]K, len(ig.edges))
+	for i, e := range ig.edges {
+		edges[i] = e.Key
+	}
+	coo := newCOOMatrix[K, int](ig.keys, edges)
+	for k, e := range ig.edges {
+		i, j := ig.idx[e.Tail], ig.idx[e.Head]
+		switch {
+		case !ig.digraph:
+			coo.add(i, k, 1)
+			coo.add(j, k, 1)
+		case i != j:
+			coo.add(i, k, -1)
+			coo.add(j, k, 1)
+		}
+	}
+	return &IncidenceMatrix[K]{oriented: ig.digraph, CSRMatrix: coo.ToCSR()}, nil
+}
+
+/*
+Create an undirected graph from a symmetric square matrix, vertex i has key i and its value is the i-th row key of the matrix (if any).
+Every non-zero entry (i,j) with i <= j becomes an edge between vertex i and j with the entry as weight,
+the edges are keyed 0,1,2... in row-major order.
+*/
+func NewGraphFromMatrix[K comparable, W number](name string, m *CSRMatrix[K, W]) (Graph[int, W], error) {
+	if m.rows != m.cols {
+		return nil, errShapeMismatch
+	}
+	t := m.Transpose()
+	for i := range m.indptr {
+		if m.indptr[i] != t.indptr[i] {
+			return nil, errNotSymmetric
+		}
+	}
+	for k := range m.indices {
+		if m.indices[k] != t.indices[k] || m.data[k] != t.data[k] {
+			return nil, errNotSymmetric
+		}
+	}
+	return newGraphFromMatrix(newGraph[int, W](false, name), m)
+}
+
+/*
+Create a digraph from a square matrix, vertex i has key i and its value is the i-th row key of the matrix (if any).
+Every non-zero entry (i,j) becomes an arc from vertex i to vertex j with the entry as weight,
+the arcs are keyed 0,1,2... in row-major order.
+*/
+func NewDigraphFromMatrix[K comparable, W number](name string, m *CSRMatrix[K, W]) (Digraph[int, W], error) {
+	if m.rows != m.cols {
+		return nil, errShapeMismatch
+	}
+	g, err := newGraphFromMatrix(newGraph[int, W](true, name), m)
+	if err != nil {
+		return nil, err
+	}
+	return g.(Digraph[int, W]), nil
+}
+
+func newGraphFromMatrix[K comparable, W number](g Graph[int, W], m *CSRMatrix[K, W]) (Graph[int, W], error) {
+	for i := 0; i < m.rows; i++ {
+		v := Vertex[int, W]{Key: i}
+		if m.rowKeys != nil {
+			v.Value = m.rowKeys[i]
+		}
+		if err := g.AddVertex(v); err != nil {
+			return nil, err
+		}
+	}
+	var key int
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			j := m.indices[k]
+			if !g.IsDigraph() && j < i {
+				continue
+			}
+			if err := g.AddEdge(Edge[int, W]{Key: key, Tail: i, Head: j, Weight: m.data[k]}); err != nil {
+				return nil, err
+			}
+			key++
+		}
+	}
+	return g, nil
+}
+
+func newCOOMatrix[K comparable, W number](rows, cols []K) *COOMatrix[K, W] {
+	return &COOMatrix[K, W]{rows: len(rows), cols: len(cols), rowKeys: rows, colKeys: cols}
+}
+
+func (m *COOMatrix[K, W]) add(i, j int, x W) {
+	m.row = append(m.row, i)
+	m.col = append(m.col, j)
+	m.data = append(m.data, x)
+}
+
+func (m *COOMatrix[K, W]) Shape() (int, int) {
+	return m.rows, m.cols
+}
+
+func (m *COOMatrix[K, W]) Rows() []K {
+	return m.rowKeys
+}
+
+func (m *COOMatrix[K, W]) Columns() []K {
+	return m.colKeys
+}
+
+// Return the number of stored entries.
+func (m *COOMatrix[K, W]) NNZ() int {
+	return len(m.data)
+}
+
+// Return the row indexes, column indexes and values of the stored entries.
+func (m *COOMatrix[K, W]) Entries() ([]int, []int, []W) {
+	return m.row, m.col, m.data
+}
+
+// Convert to CSR format, duplicate entries are summed and zero entries are dropped.
+func (m *COOMatrix[K, W]) ToCSR() *CSRMatrix[K, W] {
+	order := make([]int, len(m.data))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool {
+		x, y := order[a], order[b]
+		if m.row[x] != m.row[y] {
+			return m.row[x] < m.row[y]
+		}
+		return m.col[x] < m.col[y]
+	})
+	sm := &CSRMatrix[K, W]{rows: m.rows, cols: m.cols, rowKeys: m.rowKeys, colKeys: m.colKeys, indptr: make([]int, m.rows+1)}
+	for k := 0; k < len(order); {
+		i, j := m.row[order[k]], m.col[order[k]]
+		var x W
+		for ; k < len(order) && m.row[order[k]] == i && m.col[order[k]] == j; k++ {
+			x += m.data[order[k]]
+		}
+		if x != 0 {
+			sm.indices = append(sm.indices, j)
+			sm.data = append(sm.data, x)
+			sm.indptr[i+1]++
+		}
+	}
+	for i := 0; i < m.rows; i++ {
+		sm.indptr[i+1] += sm.indptr[i]
+	}
+	return sm
+}
+
+func (m *COOMatrix[K, W]) Dense() [][]W {
+	d := make([][]W, m.rows)
+	for i := range d {
+		d[i] = make([]W, m.cols)
+	}
+	for k, x := range m.data {
+		d[m.row[k]][m.col[k]] += x
+	}
+	return d
+}
+
+func (m *CSRMatrix[K, W]) Shape() (int, int) {
+	return m.rows, m.cols
+}
+
+func (m *CSRMatrix[K, W]) Rows() []K {
+	return m.rowKeys
+}
+
+func (m *CSRMatrix[K, W]) Columns() []K {
+	return m.colKeys
+}
+
+// Return the number of stored entries.
+func (m *CSRMatrix[K, W]) NNZ() int {
+	return len(m.data)
+}
+
+// Return the column indexes and values of the non-zero entries in row i.
+func (m *CSRMatrix[K, W]) Row(i int) ([]int, []W) {
+	return m.indices[m.indptr[i]:m.indptr[i+1]], m.data[m.indptr[i]:m.indptr[i+1]]
+}
+
+// Return the entry (i,j).
+func (m *CSRMatrix[K, W]) At(i, j int) W {
+	cols, data := m.Row(i)
+	if k := sort.SearchInts(cols, j); k < len(cols) && cols[k] == j {
+		return data[k]
+	}
+	var zero W
+	return zero
+}
+
+func (m *CSRMatrix[K, W]) ToCOO() *COOMatrix[K, W] {
+	coo := newCOOMatrix[K, W](m.rowKeys, m.colKeys)
+	coo.rows, coo.cols = m.rows, m.cols
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			coo.add(i, m.indices[k], m.data[k])
+		}
+	}
+	return coo
+}
+
+func (m *CSRMatrix[K, W]) Dense() [][]W {
+	d := make([][]W, m.rows)
+	for i := range d {
+		d[i] = make([]W, m.cols)
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			d[i][m.indices[k]] = m.data[k]
+		}
+	}
+	return d
+}
+
+// Return the transpose of the matrix, the row and column keys are swapped as well.
+func (m *CSRMatrix[K, W]) Transpose() *CSRMatrix[K, W] {
+	t := &CSRMatrix[K, W]{
+		rows:    m.cols,
+		cols:    m.rows,
+		rowKeys: m.colKeys,
+		colKeys: m.rowKeys,
+		indptr:  make([]int, m.cols+1),
+		indices: make([]int, len(m.indices)),
+		data:    make([]W, len(m.data)),
+	}
+	for _, j := range m.indices {
+		t.indptr[j+1]++
+	}
+	for j := 0; j < m.cols; j++ {
+		t.indptr[j+1] += t.indptr[j]
+	}
+	// rows are visited in ascending order, so the column indexes of t are sorted.
+	next := append([]int{}, t.indptr[:m.cols]...)
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			j := m.indices[k]
+			t.indices[next[j]] = i
+			t.data[next[j]] = m.data[k]
+			next[j]++
+		}
+	}
+	return t
+}
+
+// Calculate the product of the matrix and vector x.
+func (m *CSRMatrix[K, W]) MulVec(x []W) ([]W, error) {
+	if len(x) != m.cols {
+		return nil, errShapeMismatch
+	}
+	y := make([]W, m.rows)
+	for i := 0; i < m.rows; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			y[i] += m.data[k] * x[m.indices[k]]
+		}
+	}
+	return y, nil
+}
+
+/*
+Calculate the product of the matrix and matrix o, the rows of the result are keyed as m and the columns as o.
+Rows are computed one by one with a dense accumulator (Gustavson's algorithm), the cost is O(rows + cols + flops).
+*/
+func (m *CSRMatrix[K, W]) Mul(o *CSRMatrix[K, W]) (*CSRMatrix[K, W], error) {
+	if m.cols != o.rows {
+		return nil, errShapeMismatch
+	}
+	p := &CSRMatrix[K, W]{rows: m.rows, cols: o.cols, rowKeys: m.rowKeys, colKeys: o.colKeys, indptr: make([]int, m.rows+1)}
+	acc := make([]W, o.cols)
+	mark := make([]int, o.cols)
+	for j := range mark {
+		mark[j] = -1
+	}
+	var cols []int
+	for i := 0; i < m.rows; i++ {
+		cols = cols[:0]
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			x, r := m.data[k], m.indices[k]
+			for l := o.indptr[r]; l < o.indptr[r+1]; l++ {
+				j := o.indices[l]
+				if mark[j] != i {
+					mark[j] = i
+					acc[j] = 0
+					cols = append(cols, j)
+				}
+				acc[j] += x * o.data[l]
+			}
+		}
+		sort.Ints(cols)
+		for _, j := range cols {
+			if acc[j] != 0 {
+				p.indices = append(p.indices, j)
+				p.data = append(p.data, acc[j])
+			}
+		}
+		p.indptr[i+1] = len(p.indices)
+	}
+	return p, nil
+}
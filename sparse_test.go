@@ -0,0 +1,152 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSparseMatrix(t *testing.T) {
+	r := rand.New(rand.NewSource(46))
+	for round := 0; round < 20; round++ {
+		n := 1 + r.Intn(12)
+		var es [][3]int
+		for i := 0; i < n; i++ {
+			for j := i + 1; j < n; j++ {
+				if r.Intn(3) == 0 {
+					es = append(es, [3]int{i, j, 1 + r.Intn(9)})
+				}
+			}
+		}
+		for _, digraph := range []bool{false, true} {
+			g := testMultigraph(digraph, n, es)
+			am, err := NewAdjacencytMatrix(g)
+			if err != nil {
+				panic(err)
+			}
+			sm, err := NewSparseAdjacencyMatrix(g)
+			if err != nil {
+				panic(err)
+			}
+			pos := make(map[int]int)
+			for i, k := range sm.Columns() {
+				pos[k] = i
+			}
+			for i, u := range am.Columns() {
+				for j, v := range am.Columns() {
+					if am.Matrix()[i][j] != sm.At(pos[u], pos[v]) {
+						panic(fmt.Sprintf("entry (%d,%d) of sparse adjacency matrix is wrong", u, v))
+					}
+				}
+			}
+
+			// (A*A)*x = A*(A*x)
+			x := make([]int, n)
+			for i := range x {
+				x[i] = r.Intn(10) - 5
+			}
+			aa, err := sm.Mul(sm)
+			if err != nil {
+				panic(err)
+			}
+			y1, _ := aa.MulVec(x)
+			ax, _ := sm.MulVec(x)
+			y2, _ := sm.MulVec(ax)
+			if fmt.Sprint(y1) != fmt.Sprint(y2) {
+				panic(fmt.Sprintf("matrix product is wrong: %v %v", y1, y2))
+			}
+			if fmt.Sprint(sm.Transpose().Transpose().Dense()) != fmt.Sprint(sm.Dense()) ||
+				fmt.Sprint(sm.ToCOO().ToCSR().Dense()) != fmt.Sprint(sm.Dense()) {
+				panic("conversion of sparse matrix is wrong")
+			}
+
+			// B*B^T is the Laplacian (oriented) or the signless Laplacian (unoriented).
+			b, err := NewIncidenceMatrix(g)
+			if err != nil {
+				panic(err)
+			}
+			if b.Oriented() != digraph || len(b.Columns()) != g.Size() {
+				panic("unexpected incidence matrix")
+			}
+			bb, err := b.Mul(b.Transpose())
+			if err != nil {
+				panic(err)
+			}
+			kind := SignlessLaplacian
+			if digraph {
+				kind = CombinatorialLaplacian
+			}
+			lm, err := NewLaplacianMatrix(g, kind, false)
+			if err != nil {
+				panic(err)
+			}
+			for k, u := range b.Rows() {
+				pos[u] = k
+			}
+			for i, u := range lm.Columns() {
+				for j, v := range lm.Columns() {
+					if float64(bb.At(pos[u], pos[v])) != lm.Matrix()[i][j] {
+						panic(fmt.Sprintf("entry (%d,%d) of B*B^T is wrong", u, v))
+					}
+				}
+			}
+
+			// matrix -> graph -> matrix
+			wm, err := NewSparseWeightMatrix(g)
+			if err != nil {
+				panic(err)
+			}
+			var h Graph[int, int]
+			if digraph {
+				h, err = NewDigraphFromMatrix("h", wm)
+			} else {
+				h, err = NewGraphFromMatrix("h", wm)
+			}
+			if err != nil {
+				panic(err)
+			}
+			if h.Order() != n || h.Size() != len(es) || h.IsDigraph() != digraph {
+				panic("unexpected graph from matrix")
+			}
+			for _, e := range h.AllEdges() {
+				u, _ := h.GetVertex(e.Tail)
+				v, _ := h.GetVertex(e.Head)
+				w := wm.At(e.Tail, e.Head)
+				if wm.Rows()[e.Tail] != u.Value || wm.Rows()[e.Head] != v.Value || w != e.Weight {
+					panic(fmt.Sprintf("unexpected edge %v", e))
+				}
+			}
+		}
+	}
+
+	m, err := NewCSRMatrix([][]int{{0, 1}, {2, 0}}, nil, []string{"a", "b"})
+	if err != nil {
+		panic(err)
+	}
+	if _, err = NewGraphFromMatrix("g", m); err != errNotSymmetric {
+		panic("expect errNotSymmetric")
+	}
+	if _, err = m.MulVec([]int{1}); err != errShapeMismatch {
+		panic("expect errShapeMismatch")
+	}
+	if _, err = NewSparseWeightMatrix(testMultigraph(false, 2, [][3]int{{0, 1, 1}, {0, 1, 2}})); err != errNotSimple {
+		panic("expect errNotSimple")
+	}
+	fmt.Println("=======> test sparse matrix pass")
+}
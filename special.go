@@ -293,7 +293,7 @@ func (bg *bipartite[K, W]) Sinks() ([]Vertex[K, W], error) {
 }
 
 func (bg *bipartite[K, W]) DetectCycle() ([][]K, error) {
-	return nil, errNotImplement
+	return bg.g.DetectCycle()
 }
 
 func (bg *bipartite[K, W]) Recerse() error {
@@ -0,0 +1,340 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math"
+	"sort"
+)
+
+type LaplacianKind int
+
+const (
+	CombinatorialLaplacian LaplacianKind = iota // L = D - A
+	NormalizedLaplacian                         // I - D^(-1/2) A D^(-1/2)
+	SignlessLaplacian                           // Q = D + A
+)
+
+type LaplacianMatrix[K comparable] struct {
+	kind     LaplacianKind
+	vertexes []K
+	data     [][]float64
+}
+
+func (m *LaplacianMatrix[K]) Kind() LaplacianKind {
+	return m.kind
+}
+
+func (m *LaplacianMatrix[K]) Matrix() [][]float64 {
+	return m.data
+}
+
+func (m *LaplacianMatrix[K]) Columns() []K {
+	return m.vertexes
+}
+
+// Create the Laplacian matrix of the given kind for graph.
+// The direction of arcs is ignored, parallel edges are merged and loops are skipped.
+// If weighted is true the entry of adjacent vertexes u,v is the sum of the weights of the edges between them,
+// otherwise the number of such edges; negative weights are not allowed.
+func NewLaplacianMatrix[K comparable, W number](g Graph[K, W], kind LaplacianKind, weighted bool) (*LaplacianMatrix[K], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	vs := g.AllVertexes()
+	n := len(vs)
+	lm := &LaplacianMatrix[K]{
+		kind:     kind,
+		vertexes: make([]K, n),
+		data:     make([][]float64, n),
+	}
+	idx := make(map[K]int)
+	for i, v := range vs {
+		idx[v.Key] = i
+		lm.vertexes[i] = v.Key
+		lm.data[i] = make([]float64, n)
+	}
+	// collect the adjacency matrix first, the degrees are the row sums.
+	degree := make([]float64, n)
+	for _, e := range g.AllEdges() {
+		i, j := idx[e.Tail], idx[e.Head]
+		if i == j {
+			continue
+		}
+		w := 1.0
+		if weighted {
+			if w = float64(e.Weight); w < 0 {
+				return nil, errNegativeWeight
+			}
+		}
+		lm.data[i][j] += w
+		lm.data[j][i] += w
+		degree[i] += w
+		degree[j] += w
+	}
+
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			switch kind {
+			case SignlessLaplacian:
+			case NormalizedLaplacian:
+				if lm.data[i][j] != 0 {
+					lm.data[i][j] = -lm.data[i][j] / math.Sqrt(degree[i]*degree[j])
+				}
+			default:
+				lm.data[i][j] = -lm.data[i][j]
+			}
+		}
+		lm.data[i][i] = degree[i]
+		if kind == NormalizedLaplacian && degree[i] > 0 {
+			lm.data[i][i] = 1
+		}
+	}
+	return lm, nil
+}
+
+/*
+Calculate all eigenvalues and eigenvectors of a real symmetric matrix with the cyclic Jacobi method.
+The eigenvalues are returned in ascending order and vectors[i] is the unit eigenvector of values[i].
+Every sweep annihilates each off-diagonal entry once by a plane rotation and costs O(n^3),
+the iteration stops when the off-diagonal entries are negligible, which usually happens within 10 sweeps.
+*/
+func EigenSymmetric(m [][]float64) ([]float64, [][]float64, error) {
+	n := len(m)
+	a := make([][]float64, n)
+	v := make([][]float64, n)
+	var norm float64
+	for i := range m {
+		if len(m[i]) != n {
+			return nil, nil, errNotSymmetric
+		}
+		a[i] = append([]float64{}, m[i]...)
+		v[i] = make([]float64, n)
+		v[i][i] = 1
+		for j := range m[i] {
+			norm += m[i][j] * m[i][j]
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if math.Abs(m[i][j]-m[j][i]) > 1e-9*(1+math.Abs(m[i][j])) {
+				return nil, nil, errNotSymmetric
+			}
+		}
+	}
+
+	converged := false
+	for sweep := 0; sweep < 100 && !converged; sweep++ {
+		var off float64
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				off += a[p][q] * a[p][q]
+			}
+		}
+		if converged = off <= 1e-30*norm || off < 1e-300; converged {
+			break
+		}
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if a[p][q] == 0 {
+					continue
+				}
+				// choose the rotation angle so that the new a[p][q] is zero, t = tan(angle).
+				theta := (a[q][q] - a[p][p]) / (2 * a[p][q])
+				t := 1 / (math.Abs(theta) + math.Sqrt(theta*theta+1))
+				if theta < 0 {
+					t = -t
+				}
+				c := 1 / math.Sqrt(t*t+1)
+				s := t * c
+				// A = J^T A J, V = V J
+				for k := 0; k < n; k++ {
+					akp, akq := a[k][p], a[k][q]
+					a[k][p], a[k][q] = c*akp-s*akq, s*akp+c*akq
+				}
+				for k := 0; k < n; k++ {
+					apk, aqk := a[p][k], a[q][k]
+					a[p][k], a[q][k] = c*apk-s*aqk, s*apk+c*aqk
+				}
+				a[p][q], a[q][p] = 0, 0
+				for k := 0; k < n; k++ {
+					vkp, vkq := v[k][p], v[k][q]
+					v[k][p], v[k][q] = c*vkp-s*vkq, s*vkp+c*vkq
+				}
+			}
+		}
+	}
+	if !converged {
+		return nil, nil, errNotConverged
+	}
+
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return a[order[i]][order[i]] < a[order[j]][order[j]] })
+	values := make([]float64, n)
+	vectors := make([][]float64, n)
+	for i, c := range order {
+		values[i] = a[c][c]
+		vectors[i] = make([]float64, n)
+		sign := 0.0
+		for k := 0; k < n; k++ {
+			// fix the sign so that the first significant component is positive.
+			if sign == 0 && math.Abs(v[k][c]) > 1e-9 {
+				sign = math.Copysign(1, v[k][c])
+			}
+			vectors[i][k] = v[k][c]
+		}
+		if sign < 0 {
+			for k := range vectors[i] {
+				vectors[i][k] = -vectors[i][k]
+			}
+		}
+	}
+	return values, vectors, nil
+}
+
+// Calculate the spectrum of the Laplacian matrix of the given kind, the eigenvalues are in ascending order.
+func LaplacianSpectrum[K comparable, W number](g Graph[K, W], kind LaplacianKind, weighted bool) ([]float64, error) {
+	lm, err := NewLaplacianMatrix(g, kind, weighted)
+	if err != nil {
+		return nil, err
+	}
+	values, _, err := EigenSymmetric(lm.data)
+	return values, err
+}
+
+// Calculate the spectrum of the adjacency matrix, the eigenvalues are in ascending order.
+// The direction of arcs is ignored and a loop contributes 2 to the diagonal.
+func AdjacencySpectrum[K comparable, W number](g Graph[K, W]) ([]float64, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+	}
+	for _, e := range ig.edges {
+		i, j := ig.idx[e.Tail], ig.idx[e.Head]
+		a[i][j]++
+		a[j][i]++
+	}
+	values, _, err := EigenSymmetric(a)
+	return values, err
+}
+
+// Calculate the algebraic connectivity of graph, i.e. the second smallest eigenvalue of the Laplacian matrix,
+// it is positive if and only if the graph is connected.
+func AlgebraicConnectivity[K comparable, W number](g Graph[K, W], weighted bool) (float64, error) {
+	values, err := LaplacianSpectrum(g, CombinatorialLaplacian, weighted)
+	if err != nil {
+		return 0, err
+	}
+	if len(values) < 2 {
+		return 0, nil
+	}
+	return math.Max(values[1], 0), nil
+}
+
+// Calculate the Fiedler vector of graph, i.e. the unit eigenvector of the algebraic connectivity.
+// The vector is not unique if the algebraic connectivity is a multiple eigenvalue.
+func FiedlerVector[K comparable, W number](g Graph[K, W], weighted bool) (map[K]float64, error) {
+	lm, vectors, err := laplacianEigenvectors(g, weighted)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[K]float64, len(lm.vertexes))
+	for i, v := range lm.vertexes {
+		if len(vectors) > 1 {
+			res[v] = vectors[1][i]
+		} else {
+			res[v] = 0
+		}
+	}
+	return res, nil
+}
+
+/*
+Split the vertexes of graph into two parts of (almost) equal size by the Fiedler vector:
+the vertexes are sorted by their components and the first half forms the first part.
+Vertexes joined by heavy edges tend to have close components, so few edges cross the cut.
+*/
+func SpectralBisection[K comparable, W number](g Graph[K, W], weighted bool) ([]K, []K, error) {
+	lm, vectors, err := laplacianEigenvectors(g, weighted)
+	if err != nil {
+		return nil, nil, err
+	}
+	n := len(lm.vertexes)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	if n > 1 {
+		f := vectors[1]
+		sort.SliceStable(order, func(i, j int) bool { return f[order[i]] < f[order[j]] })
+	}
+	var p1, p2 []K
+	for i, v := range order {
+		if i < (n+1)/2 {
+			p1 = append(p1, lm.vertexes[v])
+		} else {
+			p2 = append(p2, lm.vertexes[v])
+		}
+	}
+	return p1, p2, nil
+}
+
+/*
+Calculate the spectral layout of graph: the coordinates of a vertex are its components in the eigenvectors
+of the second and third smallest eigenvalues of the Laplacian matrix. Each coordinate lies in [-1,1].
+A graph with less than 3 vertexes is placed on the x axis.
+*/
+func SpectralLayout[K comparable, W number](g Graph[K, W], weighted bool) (map[K][2]float64, error) {
+	lm, vectors, err := laplacianEigenvectors(g, weighted)
+	if err != nil {
+		return nil, err
+	}
+	pos := make(map[K][2]float64, len(lm.vertexes))
+	for i, v := range lm.vertexes {
+		var xy [2]float64
+		for d := 0; d < 2; d++ {
+			if d+1 < len(vectors) {
+				xy[d] = vectors[d+1][i]
+			}
+		}
+		pos[v] = xy
+	}
+	return pos, nil
+}
+
+func laplacianEigenvectors[K comparable, W number](g Graph[K, W], weighted bool) (*LaplacianMatrix[K], [][]float64, error) {
+	lm, err := NewLaplacianMatrix(g, CombinatorialLaplacian, weighted)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(lm.vertexes) == 0 {
+		return nil, nil, errEmptyGraph
+	}
+	_, vectors, err := EigenSymmetric(lm.data)
+	if err != nil {
+		return nil, nil, err
+	}
+	return lm, vectors, nil
+}
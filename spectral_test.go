@@ -0,0 +1,218 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func checkSpectrum(name string, got, want []float64) {
+	sort.Float64s(want)
+	if len(got) != len(want) {
+		panic(fmt.Sprintf("%s: expect %d eigenvalues, got %d", name, len(want), len(got)))
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > 1e-8 {
+			panic(fmt.Sprintf("%s: expect spectrum %v, got %v", name, want, got))
+		}
+	}
+}
+
+func TestEigenSymmetric(t *testing.T) {
+	r := rand.New(rand.NewSource(45))
+	for _, n := range []int{1, 2, 3, 8, 30} {
+		a := make([][]float64, n)
+		for i := range a {
+			a[i] = make([]float64, n)
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				a[i][j] = r.Float64()*2 - 1
+				a[j][i] = a[i][j]
+			}
+		}
+		values, vectors, err := EigenSymmetric(a)
+		if err != nil {
+			panic(err)
+		}
+		for k := range values {
+			if k > 0 && values[k] < values[k-1] {
+				panic(fmt.Sprintf("eigenvalues are not sorted: %v", values))
+			}
+			// A*v = lambda*v and |v| = 1
+			var norm float64
+			for i := 0; i < n; i++ {
+				var s float64
+				for j := 0; j < n; j++ {
+					s += a[i][j] * vectors[k][j]
+				}
+				if math.Abs(s-values[k]*vectors[k][i]) > 1e-9 {
+					panic(fmt.Sprintf("n=%d: vector %d is not an eigenvector of %f", n, k, values[k]))
+				}
+				norm += vectors[k][i] * vectors[k][i]
+			}
+			if math.Abs(norm-1) > 1e-9 {
+				panic(fmt.Sprintf("n=%d: vector %d is not unit", n, k))
+			}
+		}
+	}
+	if _, _, err := EigenSymmetric([][]float64{{1, 2}, {3, 4}}); err != errNotSymmetric {
+		panic("expect errNotSymmetric")
+	}
+	fmt.Println("=======> test eigen symmetric pass")
+}
+
+func TestLaplacianSpectrum(t *testing.T) {
+	n := 7
+	path := testMultigraph(false, n, nil)
+	cycle := testMultigraph(false, n, nil)
+	var wantPath, wantCycle []float64
+	for i := 0; i < n; i++ {
+		if i+1 < n {
+			path.AddEdge(Edge[int, int]{Key: i, Tail: i, Head: i + 1, Weight: 1})
+		}
+		cycle.AddEdge(Edge[int, int]{Key: i, Tail: i, Head: (i + 1) % n, Weight: 1})
+		wantPath = append(wantPath, 2-2*math.Cos(float64(i)*math.Pi/float64(n)))
+		wantCycle = append(wantCycle, 2-2*math.Cos(float64(2*i)*math.Pi/float64(n)))
+	}
+	values, err := LaplacianSpectrum(path, CombinatorialLaplacian, false)
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("path", values, wantPath)
+	values, err = LaplacianSpectrum(cycle, CombinatorialLaplacian, false)
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("cycle", values, wantCycle)
+	// Q and L of a bipartite graph have the same spectrum.
+	even := CompleteBipartite(3, 4)
+	l, err := LaplacianSpectrum(even, CombinatorialLaplacian, false)
+	if err != nil {
+		panic(err)
+	}
+	q, err := LaplacianSpectrum(even, SignlessLaplacian, false)
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("signless", q, l)
+	checkSpectrum("complete bipartite", l, []float64{0, 3, 3, 3, 4, 4, 7})
+
+	k := CompleteGraph(5)
+	values, err = LaplacianSpectrum(k, NormalizedLaplacian, false)
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("normalized", values, []float64{0, 1.25, 1.25, 1.25, 1.25})
+	values, err = AdjacencySpectrum(PetersenGraph())
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("petersen", values, []float64{-2, -2, -2, -2, 1, 1, 1, 1, 1, 3})
+
+	// weights multiply the spectrum, parallel edges are merged.
+	w := testMultigraph(false, 3, [][3]int{{0, 1, 2}, {1, 2, 1}, {1, 2, 1}, {2, 0, 2}, {0, 0, 5}})
+	values, err = LaplacianSpectrum(w, CombinatorialLaplacian, true)
+	if err != nil {
+		panic(err)
+	}
+	checkSpectrum("weighted", values, []float64{0, 6, 6})
+	if _, err = LaplacianSpectrum(testMultigraph(false, 2, [][3]int{{0, 1, -1}}), CombinatorialLaplacian, true); err != errNegativeWeight {
+		panic("expect errNegativeWeight")
+	}
+	fmt.Println("=======> test laplacian spectrum pass")
+}
+
+func TestFiedler(t *testing.T) {
+	// two K5 joined by the edge 0-5.
+	var es [][3]int
+	for c := 0; c < 10; c += 5 {
+		for i := 0; i < 5; i++ {
+			for j := i + 1; j < 5; j++ {
+				es = append(es, [3]int{c + i, c + j, 1})
+			}
+		}
+	}
+	g := testMultigraph(false, 10, append(es, [3]int{0, 5, 1}))
+	a, err := AlgebraicConnectivity(g, false)
+	if err != nil {
+		panic(err)
+	}
+	if a <= 0 || a >= 1 {
+		panic(fmt.Sprintf("unexpected algebraic connectivity %f", a))
+	}
+	p1, p2, err := SpectralBisection(g, false)
+	if err != nil {
+		panic(err)
+	}
+	sort.Ints(p1)
+	sort.Ints(p2)
+	if fmt.Sprint(p1, p2) != "[0 1 2 3 4] [5 6 7 8 9]" && fmt.Sprint(p2, p1) != "[0 1 2 3 4] [5 6 7 8 9]" {
+		panic(fmt.Sprintf("unexpected bisection %v %v", p1, p2))
+	}
+	f, err := FiedlerVector(g, false)
+	if err != nil {
+		panic(err)
+	}
+	for i := 1; i < 5; i++ {
+		if f[i]*f[i+5] >= 0 {
+			panic(fmt.Sprintf("unexpected fiedler vector %v", f))
+		}
+	}
+
+	// disconnected graph
+	a, err = AlgebraicConnectivity(testMultigraph(false, 4, [][3]int{{0, 1, 1}, {2, 3, 1}}), false)
+	if err != nil || a != 0 {
+		panic(fmt.Sprintf("expect 0, got %f %v", a, err))
+	}
+	a, err = AlgebraicConnectivity(CompleteGraph(6), false)
+	if err != nil || math.Abs(a-6) > 1e-9 {
+		panic(fmt.Sprintf("expect 6, got %f %v", a, err))
+	}
+	fmt.Println("=======> test fiedler pass")
+}
+
+func TestSpectralLayout(t *testing.T) {
+	// the vertexes of a cycle are placed on a circle in order.
+	n := 8
+	var es [][3]int
+	for i := 0; i < n; i++ {
+		es = append(es, [3]int{i, (i + 1) % n, 1})
+	}
+	pos, err := SpectralLayout(testMultigraph(false, n, es), false)
+	if err != nil {
+		panic(err)
+	}
+	r := math.Hypot(pos[0][0], pos[0][1])
+	for i := 0; i < n; i++ {
+		a, b := pos[i], pos[(i+1)%n]
+		if math.Abs(math.Hypot(a[0], a[1])-r) > 1e-9 {
+			panic(fmt.Sprintf("vertex %d is not on the circle: %v", i, pos))
+		}
+		if math.Abs(math.Hypot(a[0]-b[0], a[1]-b[1])-2*r*math.Sin(math.Pi/float64(n))) > 1e-9 {
+			panic(fmt.Sprintf("vertex %d and %d are not adjacent on the circle: %v", i, (i+1)%n, pos))
+		}
+	}
+	if _, err = SpectralLayout(testMultigraph(false, 0, nil), false); err != errEmptyGraph {
+		panic("expect errEmptyGraph")
+	}
+	fmt.Println("=======> test spectral layout pass")
+}
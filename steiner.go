@@ -0,0 +1,327 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math"
+	"math/bits"
+)
+
+// terminal sets with at most steinerExactLimit terminals can be solved by Dreyfus-Wagner algorithm.
+const steinerExactLimit = 16
+
+func checkSteinerInput[K comparable, W number](g Graph[K, W], terminals []K) ([]K, error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if g.IsDigraph() {
+		return nil, errDigraph
+	}
+	p, err := g.Property(ProNegativeWeight)
+	if err != nil {
+		return nil, err
+	}
+	if p.Value.(bool) {
+		return nil, errNegativeWeight
+	}
+	var ts []K
+	seen := make(map[K]bool)
+	for _, t := range terminals {
+		if _, err := g.GetVertex(t); err != nil {
+			return nil, err
+		}
+		if !seen[t] {
+			seen[t] = true
+			ts = append(ts, t)
+		}
+	}
+	return ts, nil
+}
+
+// Build a tree from the edges which connect all terminals:
+// compute the minimum spanning tree of the subgraph formed by the edges,
+// then remove non-terminal leaves repeatedly.
+func steinerFinalize[K comparable, W number](g Graph[K, W], terminals []K, edges map[K]Edge[K, W]) ([]Edge[K, W], W, error) {
+	var w W
+	h := NewGraph[K, W](false, "")
+	for _, e := range edges {
+		for _, v := range []K{e.Head, e.Tail} {
+			if _, err := h.GetVertex(v); err != nil {
+				if err := h.AddVertex(Vertex[K, W]{Key: v}); err != nil {
+					return nil, w, err
+				}
+			}
+		}
+		if err := h.AddEdge(e); err != nil {
+			return nil, w, err
+		}
+	}
+	es, _, err := MinWeightSpanningTree(h)
+	if err != nil {
+		return nil, w, err
+	}
+	terminal := make(map[K]bool)
+	for _, t := range terminals {
+		terminal[t] = true
+	}
+	degree := make(map[K]int)
+	incident := make(map[K][]int)
+	for i, e := range es {
+		degree[e.Head]++
+		degree[e.Tail]++
+		incident[e.Head] = append(incident[e.Head], i)
+		incident[e.Tail] = append(incident[e.Tail], i)
+	}
+	removed := make([]bool, len(es))
+	var leaves []K
+	for v, d := range degree {
+		if d == 1 && !terminal[v] {
+			leaves = append(leaves, v)
+		}
+	}
+	for len(leaves) > 0 {
+		v := leaves[len(leaves)-1]
+		leaves = leaves[:len(leaves)-1]
+		for _, i := range incident[v] {
+			if removed[i] {
+				continue
+			}
+			removed[i] = true
+			u := es[i].Head
+			if u == v {
+				u = es[i].Tail
+			}
+			degree[v]--
+			if degree[u]--; degree[u] == 1 && !terminal[u] {
+				leaves = append(leaves, u)
+			}
+		}
+	}
+	var res []Edge[K, W]
+	for i, e := range es {
+		if !removed[i] {
+			res = append(res, e)
+			w += e.Weight
+		}
+	}
+	return res, w, nil
+}
+
+/*
+Calculate an approximate minimum Steiner tree of a weighted undirected graph, i.e. a tree connecting all terminals with minimum total weight,
+the weight of the result is at most 2(1-1/k) times the optimum where k is the number of terminals (Kou, Markowsky and Berman).
+
+1. Construct the metric closure of terminals, i.e. the complete graph on terminals where the weight of edge (s,t)
+is the shortest distance between s and t in g.
+2. Find the minimum spanning tree of the metric closure, and replace every edge with the corresponding shortest path in g.
+3. Find the minimum spanning tree of the subgraph formed by these paths, and delete non-terminal leaves repeatedly.
+
+Return the edges of the tree and its weight. Negative weights are not allowed,
+and if terminals are not connected an error will be returned.
+*/
+func SteinerTree[K comparable, W number](g Graph[K, W], terminals []K) ([]Edge[K, W], W, error) {
+	var w W
+	ts, err := checkSteinerInput(g, terminals)
+	if err != nil {
+		return nil, w, err
+	}
+	if len(ts) < 2 {
+		return []Edge[K, W]{}, w, nil
+	}
+	idx := make(map[K]int)
+	for i, t := range ts {
+		idx[t] = i
+	}
+	// metric closure, edges are keyed by their index in paths.
+	closure := NewGraph[int, W](false, "")
+	for i := range ts {
+		if err := closure.AddVertex(Vertex[int, W]{Key: i}); err != nil {
+			return nil, w, err
+		}
+	}
+	var paths []Path[K, W]
+	for i, s := range ts {
+		ps, err := ShortestPaths(g, s)
+		if err != nil {
+			return nil, w, err
+		}
+		for _, p := range ps {
+			j, ok := idx[p.Target]
+			if !ok || j <= i {
+				continue
+			}
+			if err := closure.AddEdge(Edge[int, W]{Key: len(paths), Tail: i, Head: j, Weight: p.Weight}); err != nil {
+				return nil, w, err
+			}
+			paths = append(paths, p)
+		}
+	}
+	connected, err := closure.Property(ProConnected)
+	if err != nil {
+		return nil, w, err
+	}
+	if !connected.Value.(bool) {
+		return nil, w, errNotConnected
+	}
+	mst, _, err := MinWeightSpanningTree(closure)
+	if err != nil {
+		return nil, w, err
+	}
+	edges := make(map[K]Edge[K, W])
+	for _, e := range mst {
+		for _, k := range paths[e.Key].Edges {
+			if _, ok := edges[k]; ok {
+				continue
+			}
+			if edges[k], err = g.GetEdgeByKey(k); err != nil {
+				return nil, w, err
+			}
+		}
+	}
+	return steinerFinalize(g, ts, edges)
+}
+
+/*
+Calculate the minimum Steiner tree of a weighted undirected graph exactly by Dreyfus-Wagner algorithm,
+which takes O(3^k n + 2^k n^2 + n^3) time for k terminals, so it's only suitable for a small number of terminals.
+
+Let d(u,v) be the shortest distance, and dp[S][v] be the minimum weight of a tree connecting terminal set S and vertex v, then
+
+	dp[{t}][v] = d(t,v)
+	dp[S][v] = min{ dp[A][u] + dp[S-A][u] + d(u,v) | u in V, A is a non-empty proper subset of S }
+
+and the answer is dp[T-{t}][t] for any terminal t.
+
+Return the edges of the tree and its weight. Negative weights are not allowed,
+and if terminals are not connected an error will be returned.
+At most steinerExactLimit(16) terminals are supported.
+*/
+func SteinerTreeExact[K comparable, W number](g Graph[K, W], terminals []K) ([]Edge[K, W], W, error) {
+	var w W
+	ts, err := checkSteinerInput(g, terminals)
+	if err != nil {
+		return nil, w, err
+	}
+	if len(ts) < 2 {
+		return []Edge[K, W]{}, w, nil
+	}
+	if len(ts) > steinerExactLimit {
+		return nil, w, errTooManyVertexes
+	}
+	ig := newIndexedGraph(g)
+	n := ig.order()
+	// all pairs shortest paths (Floyd-Warshall), next[u][v] is the edge of the first step from u to v.
+	inf := math.Inf(1)
+	dist := make([][]float64, n)
+	next := make([][]int, n)
+	for u := 0; u < n; u++ {
+		dist[u] = make([]float64, n)
+		next[u] = make([]int, n)
+		for v := 0; v < n; v++ {
+			dist[u][v], next[u][v] = inf, -1
+		}
+		dist[u][u] = 0
+		for _, a := range ig.out[u] {
+			if a.to != u && a.weight < dist[u][a.to] {
+				dist[u][a.to], next[u][a.to] = a.weight, a.edge
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		for u := 0; u < n; u++ {
+			if dist[u][k] == inf {
+				continue
+			}
+			for v := 0; v < n; v++ {
+				if d := dist[u][k] + dist[k][v]; d < dist[u][v] {
+					dist[u][v], next[u][v] = d, next[u][k]
+				}
+			}
+		}
+	}
+	k := len(ts) - 1
+	root := ig.idx[ts[k]]
+	for _, t := range ts[:k] {
+		if dist[ig.idx[t]][root] == inf {
+			return nil, w, errNotConnected
+		}
+	}
+	full := 1<<k - 1
+	dp := make([][]float64, full+1)
+	// split[S][u] is the subset A at the best merge vertex u, and via[S][v] is the best merge vertex for v.
+	split := make([][]int, full+1)
+	via := make([][]int, full+1)
+	for s := 1; s <= full; s++ {
+		dp[s] = make([]float64, n)
+		split[s] = make([]int, n)
+		via[s] = make([]int, n)
+		if bits.OnesCount(uint(s)) == 1 {
+			t := ig.idx[ts[bits.TrailingZeros(uint(s))]]
+			for v := 0; v < n; v++ {
+				dp[s][v], via[s][v] = dist[t][v], t
+			}
+			continue
+		}
+		merge := make([]float64, n)
+		for u := 0; u < n; u++ {
+			merge[u] = inf
+			// enumerate subsets A containing the lowest bit of s to avoid symmetric duplicates.
+			low := s & -s
+			for a := (s - 1) & s; a > 0; a = (a - 1) & s {
+				if a&low == 0 {
+					continue
+				}
+				if c := dp[a][u] + dp[s^a][u]; c < merge[u] {
+					merge[u], split[s][u] = c, a
+				}
+			}
+		}
+		for v := 0; v < n; v++ {
+			dp[s][v] = inf
+			for u := 0; u < n; u++ {
+				if c := merge[u] + dist[u][v]; c < dp[s][v] {
+					dp[s][v], via[s][v] = c, u
+				}
+			}
+		}
+	}
+	edges := make(map[K]Edge[K, W])
+	addPath := func(u, v int) {
+		for u != v {
+			e := ig.edges[next[u][v]]
+			edges[e.Key] = e
+			if ig.idx[e.Tail] == u {
+				u = ig.idx[e.Head]
+			} else {
+				u = ig.idx[e.Tail]
+			}
+		}
+	}
+	var build func(s, v int)
+	build = func(s, v int) {
+		u := via[s][v]
+		addPath(u, v)
+		if bits.OnesCount(uint(s)) == 1 {
+			return
+		}
+		a := split[s][u]
+		build(a, u)
+		build(s^a, u)
+	}
+	build(full, root)
+	return steinerFinalize(g, ts, edges)
+}
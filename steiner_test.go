@@ -0,0 +1,119 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestSteinerTree(t *testing.T) {
+	// a star with center 0 and leaves 1,2,3 (weight 2), and a cycle 1-2-3 (weight 4).
+	g := NewGraph[int, int](false, "")
+	for i := 0; i < 4; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+	}
+	for i, e := range [][3]int{{0, 1, 2}, {0, 2, 2}, {0, 3, 2}, {1, 2, 4}, {2, 3, 4}, {3, 1, 4}} {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Tail: e[0], Head: e[1], Weight: e[2]})
+	}
+	es, w, err := SteinerTreeExact(g, []int{1, 2, 3})
+	if err != nil {
+		panic(err.Error())
+	}
+	if w != 6 || len(es) != 3 {
+		panic(fmt.Sprintf("steiner tree should have weight 6,but get %d %v", w, es))
+	}
+	es, w, err = SteinerTree(g, []int{1, 2, 3})
+	if err != nil {
+		panic(err.Error())
+	}
+	if w > 2*6 || len(es) == 0 {
+		panic(fmt.Sprintf("wrong approximate steiner tree %d %v", w, es))
+	}
+
+	// random graphs: the exact tree is not heavier than the approximation, which is at most twice of the exact one.
+	for round := 0; round < 30; round++ {
+		g := NewGraph[int, int](false, "")
+		n := 8
+		for i := 0; i < n; i++ {
+			_ = g.AddVertex(Vertex[int, int]{Key: i})
+		}
+		for i := 1; i < n; i++ {
+			_ = g.AddEdge(Edge[int, int]{Key: i, Tail: rand.Intn(i), Head: i, Weight: 1 + rand.Intn(9)})
+		}
+		for i := 0; i < 8; i++ {
+			u, v := rand.Intn(n), rand.Intn(n)
+			if u != v {
+				_ = g.AddEdge(Edge[int, int]{Key: n + i, Tail: u, Head: v, Weight: 1 + rand.Intn(9)})
+			}
+		}
+		ts := rand.Perm(n)[:4]
+		_, w1, err := SteinerTreeExact(g, ts)
+		if err != nil {
+			panic(err.Error())
+		}
+		es, w2, err := SteinerTree(g, ts)
+		if err != nil {
+			panic(err.Error())
+		}
+		if w1 > w2 || w2 > 2*w1 {
+			panic(fmt.Sprintf("exact weight %d and approximate weight %d", w1, w2))
+		}
+		// the tree connects all terminals.
+		uf := make(map[int]int)
+		var find func(int) int
+		find = func(x int) int {
+			if p, ok := uf[x]; ok && p != x {
+				return find(p)
+			}
+			return x
+		}
+		for _, e := range es {
+			uf[find(e.Head)] = find(e.Tail)
+		}
+		for _, t := range ts[1:] {
+			if find(t) != find(ts[0]) {
+				panic("steiner tree should connect all terminals")
+			}
+		}
+	}
+}
+
+func TestSteinerTreeExactLimit(t *testing.T) {
+	// a path with 70 vertexes, all of them are terminals.
+	g := NewGraph[int, int](false, "")
+	ts := make([]int, 70)
+	for i := 0; i < 70; i++ {
+		_ = g.AddVertex(Vertex[int, int]{Key: i})
+		ts[i] = i
+	}
+	for i := 1; i < 70; i++ {
+		_ = g.AddEdge(Edge[int, int]{Key: i, Tail: i - 1, Head: i, Weight: 1})
+	}
+	if _, _, err := SteinerTreeExact(g, ts); err != errTooManyVertexes {
+		panic(fmt.Sprintf("too many terminals should be rejected,but get %v", err))
+	}
+	// the limit itself is still accepted.
+	es, w, err := SteinerTreeExact(g, ts[:steinerExactLimit])
+	if err != nil {
+		panic(err.Error())
+	}
+	if w != steinerExactLimit-1 || len(es) != steinerExactLimit-1 {
+		panic(fmt.Sprintf("steiner tree should have weight %d,but get %d %v", steinerExactLimit-1, w, es))
+	}
+}
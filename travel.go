@@ -290,6 +290,8 @@ func TopologicalSort[K comparable, W number](g Digraph[K, W]) ([]Vertex[K, W], e
 // the layers of a normal BFS but refines the choiceof vertices inside each layer by
 // a deterministic rule.The algorithm is often used as a sub‑routine for recognizing
 // chordal graphs and for computing perfect elimination orderings.
+// The direction of arcs, loops and multiple edges are ignored, and all the vertexes of g are visited
+// (the vertexes not reachable from start are visited after the reachable ones).
 func LexBFS[K comparable, W number](g Graph[K, W], start K, f func(Vertex[K, W]) error) error {
 	// At each step a vertex is chosen according to a priority that is defined by a label stored on every vertex.
 	// The label is a finite sequence of integers that records, for each previously visited vertex,
@@ -300,66 +302,76 @@ func LexBFS[K comparable, W number](g Graph[K, W], start K, f func(Vertex[K, W])
 	if g == nil {
 		return errNilGraph
 	}
-	// A label of a vertex  is written as
-	//    L[v] = <i1,i2,...,ik>
-	// where the entries are the numbers of the steps at which neighbours of  were chosen.
-	// The sequence is kept in ascending order so that comparison of labels can be performed lexicographically.
-	elems := make(map[K]*HeapElem[K, any, []int])
-	orders := NewHeap[K, any](func(v1, v2 []int) bool {
-		n := min(len(v1), len(v2))
-		for i := 0; i < n; i++ {
-			if v1[i] == v2[i] {
-				continue
-			}
-			return v1[i] > v2[i]
-		}
-		return len(v1) > len(v2)
-	})
-	heap.Init(orders)
-	for _, v := range g.AllVertexes() {
-		if v.Key == start {
-			continue
-		}
-		he := &HeapElem[K, any, []int]{
-			Key: v.Key,
-		}
-		elems[v.Key] = he
-		heap.Push(orders, he)
+	ig := newIndexedGraph(g)
+	s, ok := ig.idx[start]
+	if !ok {
+		return errVertexNotExists
 	}
-	for i := g.Order(); len(elems) != 0; i-- {
-		var k K
-		if i != 0 {
-			el := heap.Pop(orders).(*HeapElem[K, any, []int])
-			k = el.Key
-		} else {
-			k = start
-		}
-		// visited u (the number of u is |G|-i+1)
-		u, err := g.GetVertex(k)
+	for _, v := range lexBFSOrder(ig.simpleNeighbours(), s) {
+		u, err := g.GetVertex(ig.keys[v])
 		if err != nil {
 			return err
 		}
 		if err := f(u); err != nil {
 			return err
 		}
-		delete(elems, k)
-		// After a vertex is selected, its adjacency list is traversed.
-		// For every unvisited neighbour  of , the current step index  is appended to :
-		// The step index is incremented after each selection.
-		// All other vertices’ labels remain unchanged.
-		ns, err := g.Neighbours(u.Key)
-		if err != nil {
-			return err
-		}
-		for _, v := range ns {
-			ve, ok := elems[v.Key]
-			if ok {
-				ve.Rank = append(ve.Rank, i)
-				heap.Fix(orders, ve.Idx)
+	}
+	return nil
+}
+
+// lexBFSOrder computes the LexBFS order by partition refinement in O(n+m) time.
+//
+// The unvisited vertexes are kept in an ordered sequence of classes (vertexes with the same label),
+// the next vertex is always the first vertex of the first class. When a vertex v is visited,
+// every class C is split into C∩N(v) and C-N(v), and C∩N(v) is put right before C-N(v),
+// which is equivalent to appending the step number to the labels of the neighbours of v.
+func lexBFSOrder(adj [][]int, start int) []int {
+	n := len(adj)
+	type class struct {
+		start, end int
+	}
+	ord, pos := make([]int, n), make([]int, n)
+	for v := 0; v < n; v++ {
+		ord[v], pos[v] = v, v
+	}
+	if n == 0 {
+		return ord
+	}
+	ord[0], ord[start] = start, ord[0]
+	pos[ord[0]], pos[ord[start]] = 0, start
+	// classes are contiguous ranges of ord, split[c] is the class split from c in current step.
+	cls := []class{{0, n}}
+	split := []int{-1}
+	of := make([]int, n)
+	for i := 0; i < n; i++ {
+		v := ord[i]
+		cls[of[v]].start++
+		var touched []int
+		for _, w := range adj[v] {
+			if pos[w] <= i {
+				continue
 			}
+			c := of[w]
+			if split[c] < 0 {
+				cls = append(cls, class{cls[c].start, cls[c].start})
+				split = append(split, -1)
+				split[c] = len(cls) - 1
+				touched = append(touched, c)
+			}
+			// move w to the front of its class, and the front part becomes the new class.
+			nc, p := split[c], cls[c].start
+			u := ord[p]
+			ord[p], ord[pos[w]] = w, u
+			pos[u], pos[w] = pos[w], p
+			cls[c].start++
+			cls[nc].end++
+			of[w] = nc
+		}
+		for _, c := range touched {
+			split[c] = -1
 		}
 	}
-	return nil
+	return ord
 }
 
 func LexDFS[K comparable, W number](g Graph[K, W], start K, f func(Vertex[K, W]) error) error {
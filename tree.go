@@ -89,8 +89,8 @@ func mstPrim[K comparable, W number](g Graph[K, W]) ([]K, []Edge[K, W], W, error
 		}
 		// update weight sum.
 		wT += cU
-		v := prev[u]
-		if v != u {
+		// the root has no prev vertex.
+		if v, ok := prev[u]; ok && v != u {
 			e, _, err := getMinWeightEdge(g, u, v)
 			if err != nil {
 				return nil, nil, 0.0, err
@@ -149,8 +149,8 @@ func mstPrimWithPQ[K comparable, W number](g Graph[K, W]) ([]K, []Edge[K, W], W,
 		}
 		// update weight sum.
 		wT += c
-		v := prev[u]
-		if v != u {
+		// the root has no prev vertex.
+		if v, ok := prev[u]; ok && v != u {
 			e, _, err := getMinWeightEdge(g, u, v)
 			if err != nil {
 				return nil, nil, 0.0, err
@@ -239,8 +239,8 @@ func msfPrim[K comparable, W number](g Graph[K, W]) ([][]K, [][]Edge[K, W], []W,
 			}
 		}
 		wT += cU // update weight sum.
-		v := prev[u]
-		if v != u {
+		// the root has no prev vertex.
+		if v, ok := prev[u]; ok && v != u {
 			e, _, err := getMinWeightEdge(g, v, u)
 			if err != nil {
 				return nil, nil, nil, err
@@ -250,6 +250,12 @@ func msfPrim[K comparable, W number](g Graph[K, W]) ([][]K, [][]Edge[K, W], []W,
 			}
 		}
 	}
+	// the last tree.
+	if len(tree) != 0 {
+		trees = append(trees, tree)
+		edges = append(edges, branch)
+		wTs = append(wTs, wT)
+	}
 
 	return trees, edges, wTs, nil
 }
@@ -0,0 +1,81 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMinWeightSpanningTree(t *testing.T) {
+	// the root of Prim algorithm is the vertex 3 (the first added vertex),
+	// the zero value of keys is also a vertex, so the root must not be joined with it.
+	g := NewGraph[int, int](false, "")
+	for _, v := range []int{3, 0, 1, 2, 4, 5} {
+		_ = g.AddVertex(Vertex[int, int]{Key: v})
+	}
+	for i, e := range [][3]int{{3, 0, 2}, {0, 1, 1}, {1, 2, 1}, {2, 3, 5}, {4, 5, 3}} {
+		if err := g.AddEdge(Edge[int, int]{Key: 10 + i, Tail: e[0], Head: e[1], Weight: e[2]}); err != nil {
+			panic(err.Error())
+		}
+	}
+	trees, edges, ws, err := MinWeightSpanningForest(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(trees) != 2 {
+		panic(fmt.Sprintf("there should be 2 trees,but get %v", trees))
+	}
+	var total, size int
+	for i := range trees {
+		if len(edges[i]) != len(trees[i])-1 {
+			panic(fmt.Sprintf("tree %v should have %d edges,but get %v", trees[i], len(trees[i])-1, edges[i]))
+		}
+		var w int
+		for _, e := range edges[i] {
+			w += e.Weight
+		}
+		if w != ws[i] {
+			panic(fmt.Sprintf("weight of tree %v should be %d,but get %d", trees[i], w, ws[i]))
+		}
+		total += w
+		size += len(edges[i])
+	}
+	if total != 7 || size != 4 {
+		panic(fmt.Sprintf("wrong minimum spanning forest %v %v", edges, ws))
+	}
+
+	if err = g.RemoveVertex(4); err != nil {
+		panic(err.Error())
+	}
+	if err = g.RemoveVertex(5); err != nil {
+		panic(err.Error())
+	}
+	es, w, err := MinWeightSpanningTree(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if len(es) != 3 || w != 4 {
+		panic(fmt.Sprintf("wrong minimum spanning tree %v weight %d", es, w))
+	}
+	for _, e := range es {
+		if e.Key == 13 {
+			panic("edge (2,3) is not in the minimum spanning tree")
+		}
+	}
+	fmt.Println("=======> test minimum spanning tree pass")
+}
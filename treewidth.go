@@ -0,0 +1,275 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import "math/bits"
+
+// TreewidthHeuristic decides the elimination order used to build a tree decomposition.
+type TreewidthHeuristic int
+
+const (
+	// always eliminate the vertex with minimum degree.
+	MinDegreeHeuristic TreewidthHeuristic = iota
+	// always eliminate the vertex whose elimination adds the fewest fill edges.
+	MinFillInHeuristic
+	// compute an optimal elimination order, only for small graphs.
+	ExactTreewidth
+)
+
+// graphs with at most treewidthExactLimit vertexes can be solved exactly.
+const treewidthExactLimit = 18
+
+// eliminationGraph is the graph in which vertexes are eliminated one by one,
+// eliminating a vertex makes its neighbours a clique.
+type eliminationGraph struct {
+	nb    []map[int]struct{}
+	alive []bool
+}
+
+func newEliminationGraph(adj [][]int) *eliminationGraph {
+	eg := &eliminationGraph{
+		nb:    make([]map[int]struct{}, len(adj)),
+		alive: make([]bool, len(adj)),
+	}
+	for v, ns := range adj {
+		eg.alive[v] = true
+		eg.nb[v] = make(map[int]struct{})
+		for _, u := range ns {
+			eg.nb[v][u] = struct{}{}
+		}
+	}
+	return eg
+}
+
+// fillIn returns the number of edges added by eliminating v.
+func (eg *eliminationGraph) fillIn(v int) int {
+	ns := make([]int, 0, len(eg.nb[v]))
+	for u := range eg.nb[v] {
+		ns = append(ns, u)
+	}
+	c := 0
+	for i := range ns {
+		for j := i + 1; j < len(ns); j++ {
+			if _, ok := eg.nb[ns[i]][ns[j]]; !ok {
+				c++
+			}
+		}
+	}
+	return c
+}
+
+// eliminate removes v and returns its neighbours before elimination.
+func (eg *eliminationGraph) eliminate(v int) []int {
+	ns := make([]int, 0, len(eg.nb[v]))
+	for u := range eg.nb[v] {
+		ns = append(ns, u)
+	}
+	for i, u := range ns {
+		delete(eg.nb[u], v)
+		for _, w := range ns[i+1:] {
+			eg.nb[u][w] = struct{}{}
+			eg.nb[w][u] = struct{}{}
+		}
+	}
+	eg.alive[v] = false
+	eg.nb[v] = nil
+	return ns
+}
+
+// greedyElimination computes an elimination order by min-degree or min-fill-in heuristic,
+// ties are broken by degree and then by index.
+func greedyElimination(adj [][]int, heuristic TreewidthHeuristic) []int {
+	n := len(adj)
+	eg := newEliminationGraph(adj)
+	order := make([]int, 0, n)
+	for len(order) < n {
+		best, bestFill := -1, 0
+		for v := 0; v < n; v++ {
+			if !eg.alive[v] {
+				continue
+			}
+			fill := 0
+			if heuristic == MinFillInHeuristic {
+				fill = eg.fillIn(v)
+			}
+			if best < 0 || fill < bestFill || (fill == bestFill && len(eg.nb[v]) < len(eg.nb[best])) {
+				best, bestFill = v, fill
+			}
+		}
+		eg.eliminate(best)
+		order = append(order, best)
+	}
+	return order
+}
+
+/*
+Dynamic programming over subsets (Bodlaender, Fomin, Koster, Kratsch and Thilikos), compute the treewidth in O(2^n*n^2) time.
+
+For a set S of vertexes eliminated first, let Q(S,v) be the set of vertexes not in S∪{v} which can be reached from v
+through a path whose internal vertexes are all in S, Q(S,v) is exactly the neighbourhood of v when v is eliminated after S.
+
+	TW(∅) = -1
+	TW(S) = min{ max(TW(S-{v}), |Q(S-{v},v)|) | v ∈ S }
+
+and the treewidth of the graph is TW(V). Return the treewidth and an optimal elimination order.
+*/
+func exactTreewidth(adj [][]int) (int, []int) {
+	n := len(adj)
+	mask := make([]uint32, n)
+	for v, ns := range adj {
+		for _, u := range ns {
+			mask[v] |= 1 << u
+		}
+	}
+	q := func(s uint32, v int) int {
+		comp := uint32(1) << v
+		nb := mask[v]
+		for {
+			grow := nb & s &^ comp
+			if grow == 0 {
+				break
+			}
+			comp |= grow
+			for x := grow; x != 0; x &= x - 1 {
+				nb |= mask[bits.TrailingZeros32(x)]
+			}
+		}
+		return bits.OnesCount32(nb &^ s &^ (1 << v))
+	}
+	full := uint32(1)<<n - 1
+	tw := make([]int8, full+1)
+	last := make([]int8, full+1)
+	tw[0] = -1
+	for s := uint32(1); s <= full; s++ {
+		tw[s] = int8(n)
+		for x := s; x != 0; x &= x - 1 {
+			v := bits.TrailingZeros32(x)
+			r := s &^ (1 << v)
+			if tw[r] >= tw[s] {
+				continue
+			}
+			if c := int8(max(int(tw[r]), q(r, v))); c < tw[s] {
+				tw[s], last[s] = c, int8(v)
+			}
+		}
+	}
+	order := make([]int, n)
+	for s, i := full, n-1; s != 0; i-- {
+		v := int(last[s])
+		order[i] = v
+		s &^= 1 << v
+	}
+	return int(tw[full]), order
+}
+
+// eliminationDecomposition builds a tree decomposition from an elimination order:
+// the bag of v contains v and its neighbours when v is eliminated, and the parent of the bag is
+// the bag of the neighbour eliminated first after v. Bag i is the bag of order[i].
+func eliminationDecomposition(adj [][]int, order []int) ([][]int, []int) {
+	n := len(adj)
+	pos := make([]int, n)
+	for i, v := range order {
+		pos[v] = i
+	}
+	eg := newEliminationGraph(adj)
+	bags := make([][]int, n)
+	parent := make([]int, n)
+	for i, v := range order {
+		ns := eg.eliminate(v)
+		bags[i] = append([]int{v}, ns...)
+		parent[i] = -1
+		for _, u := range ns {
+			if parent[i] < 0 || pos[u] < parent[i] {
+				parent[i] = pos[u]
+			}
+		}
+	}
+	return bags, parent
+}
+
+// Calculate a tree decomposition of the graph by vertex elimination, the elimination order is decided by the heuristic.
+// Return the bags, a forest whose vertex i stands for bags[i] (the tree edge from parent bag to child bag
+// has the key of the child), and the width of the decomposition (maximum bag size minus one).
+// Every connected component corresponds to a tree of the forest.
+// The direction of arcs, loops and multiple edges are ignored.
+// ExactTreewidth gives a decomposition of minimum width, but only works for graphs with at most 18 vertexes.
+func TreeDecomposition[K comparable, W number](g Graph[K, W], heuristic TreewidthHeuristic) ([][]K, *Forest[int, int], int, error) {
+	if g == nil {
+		return nil, nil, 0, errNilGraph
+	}
+	if g.Order() == 0 {
+		return nil, nil, 0, errEmptyGraph
+	}
+	ig := newIndexedGraph(g)
+	adj := ig.simpleNeighbours()
+	var order []int
+	switch heuristic {
+	case MinDegreeHeuristic, MinFillInHeuristic:
+		order = greedyElimination(adj, heuristic)
+	case ExactTreewidth:
+		if len(adj) > treewidthExactLimit {
+			return nil, nil, 0, errTooManyVertexes
+		}
+		_, order = exactTreewidth(adj)
+	default:
+		return nil, nil, 0, errNotImplement
+	}
+	bs, parent := eliminationDecomposition(adj, order)
+	bags := make([][]K, len(bs))
+	width := 0
+	f := NewForest[int, int]()
+	for i, b := range bs {
+		bags[i] = make([]K, len(b))
+		for j, v := range b {
+			bags[i][j] = ig.keys[v]
+		}
+		width = max(width, len(b)-1)
+		if err := f.AddVertex(Vertex[int, int]{Key: i}); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+	for i, p := range parent {
+		if p >= 0 {
+			if err := f.AddEdge(Edge[int, int]{Key: i, Tail: p, Head: i}); err != nil {
+				return nil, nil, 0, err
+			}
+		}
+	}
+	for i, p := range parent {
+		if p < 0 {
+			f.SetRoot(i)
+		}
+	}
+	return bags, f, width, nil
+}
+
+// Calculate the treewidth of the graph exactly, the graph should have at most 18 vertexes.
+// The direction of arcs, loops and multiple edges are ignored.
+func Treewidth[K comparable, W number](g Graph[K, W]) (int, error) {
+	if g == nil {
+		return 0, errNilGraph
+	}
+	if g.Order() == 0 {
+		return 0, errEmptyGraph
+	}
+	adj := newIndexedGraph(g).simpleNeighbours()
+	if len(adj) > treewidthExactLimit {
+		return 0, errTooManyVertexes
+	}
+	tw, _ := exactTreewidth(adj)
+	return tw, nil
+}
@@ -0,0 +1,141 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// checkTreeDecomposition verifies the three conditions of tree decomposition.
+func checkTreeDecomposition(g Graph[int, int], bags [][]int, f *Forest[int, int], width int) {
+	in := make(map[int]map[int]bool)
+	w := 0
+	for i, b := range bags {
+		w = max(w, len(b)-1)
+		for _, v := range b {
+			if in[v] == nil {
+				in[v] = make(map[int]bool)
+			}
+			in[v][i] = true
+		}
+	}
+	if w != width || len(in) != g.Order() {
+		panic(fmt.Sprintf("wrong width %d or bags %v", width, bags))
+	}
+	// the vertex i of the forest is bags[i].
+	if f.Order() != len(bags) {
+		panic(fmt.Sprintf("forest should have %d vertexes,but get %d", len(bags), f.Order()))
+	}
+	for _, v := range f.AllVertexes() {
+		if v.Key < 0 || v.Key >= len(bags) {
+			panic(fmt.Sprintf("forest vertex %d is not a bag", v.Key))
+		}
+	}
+	for _, e := range g.AllEdges() {
+		found := false
+		for i := range in[e.Head] {
+			if in[e.Tail][i] {
+				found = true
+				break
+			}
+		}
+		if !found {
+			panic(fmt.Sprintf("edge %v is not in any bag", e))
+		}
+	}
+	// the bags containing v induce a subtree, i.e. the number of tree edges among them is one less.
+	for v, bs := range in {
+		c := 0
+		for _, e := range f.AllEdges() {
+			if bs[e.Head] && bs[e.Tail] {
+				c++
+			}
+		}
+		if c != len(bs)-1 {
+			panic(fmt.Sprintf("bags containing %d are not connected", v))
+		}
+	}
+}
+
+func TestTreeDecomposition(t *testing.T) {
+	var grid [][3]int
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			if j < 2 {
+				grid = append(grid, [3]int{3*i + j, 3*i + j + 1, 1})
+			}
+			if i < 2 {
+				grid = append(grid, [3]int{3*i + j, 3*i + j + 3, 1})
+			}
+		}
+	}
+	var k5 [][3]int
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			k5 = append(k5, [3]int{i, j, 1})
+		}
+	}
+	cases := []struct {
+		g  Graph[int, int]
+		tw int
+	}{
+		{testMultigraph(false, 5, [][3]int{{0, 1, 1}, {0, 2, 1}, {2, 3, 1}, {2, 4, 1}}), 1},
+		{testMultigraph(false, 5, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 0, 1}}), 2},
+		{testMultigraph(false, 5, k5), 4},
+		{testMultigraph(false, 9, grid), 3},
+		{testPetersen(), 4},
+		{testMultigraph(false, 3, nil), 0},
+	}
+	for _, c := range cases {
+		tw, err := Treewidth(c.g)
+		if err != nil {
+			panic(err.Error())
+		}
+		if tw != c.tw {
+			panic(fmt.Sprintf("treewidth of %v should be %d, not %d", c.g.AllEdges(), c.tw, tw))
+		}
+		bags, f, width, err := TreeDecomposition(c.g, ExactTreewidth)
+		if err != nil {
+			panic(err.Error())
+		}
+		if width != tw {
+			panic(fmt.Sprintf("exact decomposition has width %d", width))
+		}
+		checkTreeDecomposition(c.g, bags, f, width)
+	}
+	r := rand.New(rand.NewSource(17))
+	for round := 0; round < 100; round++ {
+		g := testRandomGraph(r, 1+r.Intn(10), r.Float64())
+		tw, err := Treewidth(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		for _, h := range []TreewidthHeuristic{MinDegreeHeuristic, MinFillInHeuristic} {
+			bags, f, width, err := TreeDecomposition(g, h)
+			if err != nil {
+				panic(err.Error())
+			}
+			checkTreeDecomposition(g, bags, f, width)
+			if width < tw {
+				panic(fmt.Sprintf("heuristic width %d is less than treewidth %d", width, tw))
+			}
+		}
+	}
+	fmt.Println("=======> test tree decomposition pass")
+}
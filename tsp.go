@@ -0,0 +1,496 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"math"
+	"time"
+)
+
+// graphs with at most tspExactLimit vertexes can be solved by Held-Karp algorithm.
+const tspExactLimit = 18
+
+// graphs with at most tspAutoExactLimit vertexes are solved exactly by TSP.
+const tspAutoExactLimit = 12
+
+const tspEpsilon = 1e-9
+
+// tspInstance is the traveling salesman problem over the metric closure of a graph,
+// dist[u][v] is the shortest distance from u to v, a tour is a permutation of the vertexes.
+type tspInstance[K comparable, W number] struct {
+	ig   *indexedGraph[K, W]
+	n    int
+	dist [][]float64
+	sss  []*singleSource
+}
+
+func newTSPInstance[K comparable, W number](g Graph[K, W]) (*tspInstance[K, W], error) {
+	if g == nil {
+		return nil, errNilGraph
+	}
+	if g.Order() == 0 {
+		return nil, errEmptyGraph
+	}
+	ig := newIndexedGraph(g)
+	if ig.hasNegativeWeight() {
+		return nil, errNegativeWeight
+	}
+	n := ig.order()
+	t := &tspInstance[K, W]{
+		ig:   ig,
+		n:    n,
+		dist: make([][]float64, n),
+		sss:  make([]*singleSource, n),
+	}
+	for u := 0; u < n; u++ {
+		t.sss[u] = ig.shortestPathDAG(u, true, true)
+		t.dist[u] = t.sss[u].dist
+		for v := 0; v < n; v++ {
+			if t.dist[u][v] < 0 {
+				return nil, errNotConnected
+			}
+		}
+	}
+	return t, nil
+}
+
+func (t *tspInstance[K, W]) cost(tour []int) float64 {
+	var c float64
+	for i, u := range tour {
+		c += t.dist[u][tour[(i+1)%len(tour)]]
+	}
+	return c
+}
+
+// path expands every step of the tour into a shortest path of the graph.
+func (t *tspInstance[K, W]) path(tour []int) Path[K, W] {
+	p := Path[K, W]{
+		Source: t.ig.keys[tour[0]],
+		Target: t.ig.keys[tour[0]],
+	}
+	if t.n == 1 {
+		return p
+	}
+	for i, u := range tour {
+		var es []int
+		for v := tour[(i+1)%t.n]; v != u; {
+			a := t.sss[u].pred[v][0]
+			es = append(es, a.edge)
+			v = a.to
+		}
+		for j := len(es) - 1; j >= 0; j-- {
+			e := t.ig.edges[es[j]]
+			p.Edges = append(p.Edges, e.Key)
+			p.Weight += e.Weight
+		}
+	}
+	return p
+}
+
+// tour recovers a tour from a closed walk, the vertexes are ordered by their first visit.
+func (t *tspInstance[K, W]) tour(p Path[K, W]) ([]int, error) {
+	cur, ok := t.ig.idx[p.Source]
+	if !ok {
+		return nil, errVertexNotExists
+	}
+	edges := make(map[K]int)
+	for i, e := range t.ig.edges {
+		edges[e.Key] = i
+	}
+	visited := make([]bool, t.n)
+	visited[cur] = true
+	tour := []int{cur}
+	for _, k := range p.Edges {
+		i, ok := edges[k]
+		if !ok {
+			return nil, errEdgeNotExists
+		}
+		e := t.ig.edges[i]
+		switch {
+		case t.ig.idx[e.Tail] == cur:
+			cur = t.ig.idx[e.Head]
+		case t.ig.idx[e.Head] == cur && !t.ig.digraph:
+			cur = t.ig.idx[e.Tail]
+		default:
+			return nil, errEdgeNotExists
+		}
+		if !visited[cur] {
+			visited[cur] = true
+			tour = append(tour, cur)
+		}
+	}
+	if len(tour) != t.n {
+		return nil, errVertexNotExists
+	}
+	return tour, nil
+}
+
+/*
+Held-Karp algorithm, solve TSP exactly in O(2^n*n^2) time.
+
+Fix vertex 0 as the start, C[S][v] is the length of the shortest path which starts from 0,
+visits exactly the vertexes of S and ends at v ∈ S:
+
+	C[{v}][v] = d(0,v)
+	C[S][v] = min{ C[S-{v}][u] + d(u,v) | u ∈ S-{v} }
+
+and the length of the optimal tour is min{ C[V-{0}][v] + d(v,0) }.
+*/
+func (t *tspInstance[K, W]) heldKarp() []int {
+	n := t.n
+	if n <= 2 {
+		tour := make([]int, n)
+		for i := range tour {
+			tour[i] = i
+		}
+		return tour
+	}
+	// vertex v (v > 0) is the bit v-1 of S.
+	m := n - 1
+	full := 1<<m - 1
+	c := make([][]float64, full+1)
+	prev := make([][]int8, full+1)
+	for s := 1; s <= full; s++ {
+		c[s] = make([]float64, m)
+		prev[s] = make([]int8, m)
+		for v := 0; v < m; v++ {
+			c[s][v] = math.Inf(1)
+			prev[s][v] = -1
+		}
+	}
+	for v := 0; v < m; v++ {
+		c[1<<v][v] = t.dist[0][v+1]
+	}
+	for s := 1; s <= full; s++ {
+		for v := 0; v < m; v++ {
+			if s&(1<<v) == 0 || s == 1<<v {
+				continue
+			}
+			r := s &^ (1 << v)
+			for u := 0; u < m; u++ {
+				if r&(1<<u) == 0 {
+					continue
+				}
+				if d := c[r][u] + t.dist[u+1][v+1]; d < c[s][v] {
+					c[s][v], prev[s][v] = d, int8(u)
+				}
+			}
+		}
+	}
+	last, best := 0, math.Inf(1)
+	for v := 0; v < m; v++ {
+		if d := c[full][v] + t.dist[v+1][0]; d < best {
+			last, best = v, d
+		}
+	}
+	tour := make([]int, n)
+	for s, i := full, n-1; i > 0; i-- {
+		tour[i] = last + 1
+		p := int(prev[s][last])
+		s &^= 1 << last
+		last = p
+	}
+	return tour
+}
+
+// Nearest neighbour heuristic, always go to the nearest unvisited vertex.
+func (t *tspInstance[K, W]) nearestNeighbour(start int) []int {
+	visited := make([]bool, t.n)
+	tour := make([]int, 0, t.n)
+	for cur := start; cur >= 0; {
+		visited[cur] = true
+		tour = append(tour, cur)
+		next, best := -1, math.Inf(1)
+		for v := 0; v < t.n; v++ {
+			if !visited[v] && t.dist[cur][v] < best {
+				next, best = v, t.dist[cur][v]
+			}
+		}
+		cur = next
+	}
+	return tour
+}
+
+/*
+Christofides algorithm, a 3/2-approximation for the metric TSP:
+
+ 1. compute a minimum spanning tree T of the metric closure.
+ 2. compute a minimum weight perfect matching M on the vertexes with odd degree in T.
+ 3. compute an eulerian circuit of the multigraph T+M.
+ 4. shortcut the circuit by skipping vertexes which have already been visited.
+*/
+func (t *tspInstance[K, W]) christofides() ([]int, error) {
+	n := t.n
+	if n <= 2 {
+		return t.heldKarp(), nil
+	}
+	closure := NewGraph[int, float64](false, "")
+	for v := 0; v < n; v++ {
+		if err := closure.AddVertex(Vertex[int, float64]{Key: v}); err != nil {
+			return nil, err
+		}
+	}
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			if err := closure.AddEdge(Edge[int, float64]{Key: u*n + v, Tail: u, Head: v, Weight: t.dist[u][v]}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	mst, _, err := MinWeightSpanningTree(closure)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]eulerLink, 0, n+n/2)
+	deg := make([]int, n)
+	for _, e := range mst {
+		links = append(links, eulerLink{tail: e.Tail, head: e.Head, edge: -1})
+		deg[e.Tail]++
+		deg[e.Head]++
+	}
+	var odd []int
+	for v := 0; v < n; v++ {
+		if deg[v]%2 == 1 {
+			odd = append(odd, v)
+		}
+	}
+	cost := make([][]float64, len(odd))
+	for i, u := range odd {
+		cost[i] = make([]float64, len(odd))
+		for j, v := range odd {
+			cost[i][j] = t.dist[u][v]
+		}
+	}
+	for i, j := range minWeightPairing(cost) {
+		if i < j {
+			links = append(links, eulerLink{tail: odd[i], head: odd[j], edge: -1})
+		}
+	}
+	walk, _ := hierholzer(n, false, links, 0)
+	visited := make([]bool, n)
+	visited[0] = true
+	tour := []int{0}
+	for cur, i := 0, 0; i < len(walk); i++ {
+		if l := links[walk[i]]; l.tail == cur {
+			cur = l.head
+		} else {
+			cur = l.tail
+		}
+		if !visited[cur] {
+			visited[cur] = true
+			tour = append(tour, cur)
+		}
+	}
+	return tour, nil
+}
+
+// segmentCost is the length of the path tour[i..j].
+func (t *tspInstance[K, W]) segmentCost(tour []int, i, j int, reversed bool) float64 {
+	var c float64
+	for k := i; k < j; k++ {
+		if reversed {
+			c += t.dist[tour[k+1]][tour[k]]
+		} else {
+			c += t.dist[tour[k]][tour[k+1]]
+		}
+	}
+	return c
+}
+
+// 2-opt move, replace edges (a,b) and (c,e) by (a,c) and (b,e), i.e. reverse the segment b..c.
+func (t *tspInstance[K, W]) twoOpt(tour []int, deadline time.Time) bool {
+	n := len(tour)
+	improved := false
+	for i := 0; i < n-2; i++ {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return improved
+		}
+		for j := i + 2; j < n; j++ {
+			if i == 0 && j == n-1 {
+				continue
+			}
+			a, b, c, e := tour[i], tour[i+1], tour[j], tour[(j+1)%n]
+			delta := t.dist[a][c] + t.dist[b][e] - t.dist[a][b] - t.dist[c][e]
+			if t.ig.digraph {
+				delta += t.segmentCost(tour, i+1, j, true) - t.segmentCost(tour, i+1, j, false)
+			}
+			if delta < -tspEpsilon {
+				for l, r := i+1, j; l < r; l, r = l+1, r-1 {
+					tour[l], tour[r] = tour[r], tour[l]
+				}
+				improved = true
+			}
+		}
+	}
+	return improved
+}
+
+// Or-opt move, move a segment of at most 3 vertexes to another position of the tour (maybe reversed).
+func (t *tspInstance[K, W]) orOpt(tour []int, deadline time.Time) bool {
+	n := len(tour)
+	improved := false
+	for l := 1; l <= 3 && l+2 <= n; l++ {
+		for i := 0; i < n; i++ {
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				return improved
+			}
+			// rotate the tour so that the segment is at the beginning.
+			rot := append(append([]int{}, tour[i:]...), tour[:i]...)
+			seg, rest := rot[:l], rot[l:]
+			s0, s1 := seg[0], seg[l-1]
+			prev, next := rest[len(rest)-1], rest[0]
+			gain := t.dist[prev][s0] + t.dist[s1][next] - t.dist[prev][next]
+			fwd := t.segmentCost(seg, 0, l-1, false)
+			rev := t.segmentCost(seg, 0, l-1, true)
+			for k := 0; k+1 < len(rest); k++ {
+				p, q := rest[k], rest[k+1]
+				add := t.dist[p][s0] + t.dist[s1][q] - t.dist[p][q]
+				reversed := false
+				if c := t.dist[p][s1] + t.dist[s0][q] - t.dist[p][q] + rev - fwd; c < add {
+					add, reversed = c, true
+				}
+				if add < gain-tspEpsilon {
+					res := make([]int, 0, n)
+					res = append(res, rest[:k+1]...)
+					if reversed {
+						for j := l - 1; j >= 0; j-- {
+							res = append(res, seg[j])
+						}
+					} else {
+						res = append(res, seg...)
+					}
+					res = append(res, rest[k+1:]...)
+					copy(tour, res)
+					improved = true
+					break
+				}
+			}
+		}
+	}
+	return improved
+}
+
+// improve applies 2-opt and Or-opt moves until no move improves the tour or the deadline is reached.
+func (t *tspInstance[K, W]) improve(tour []int, deadline time.Time) {
+	if len(tour) < 4 {
+		return
+	}
+	for {
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			return
+		}
+		a := t.twoOpt(tour, deadline)
+		b := t.orOpt(tour, deadline)
+		if !a && !b {
+			return
+		}
+	}
+}
+
+// Solve the traveling salesman problem exactly by Held-Karp algorithm, the graph should have at most 18 vertexes.
+//
+// All the TSP functions work on the metric closure of the graph: the distance of two vertexes is
+// the length of the shortest path between them, so g does not need to be complete. The returned path is a closed walk
+// which starts and ends at the same vertex and visits every vertex, if g is not complete a vertex may be passed several times.
+// g can be an undirected graph or a directed graph, negative weights are not allowed,
+// and if some vertex is not reachable from another one an error will be returned.
+func TSPHeldKarp[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	t, err := newTSPInstance(g)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	if t.n > tspExactLimit {
+		return Path[K, W]{}, errTooManyVertexes
+	}
+	return t.path(t.heldKarp()), nil
+}
+
+// Calculate a tour by nearest neighbour heuristic from the start vertex.
+func TSPNearestNeighbour[K comparable, W number](g Graph[K, W], start K) (Path[K, W], error) {
+	t, err := newTSPInstance(g)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	s, ok := t.ig.idx[start]
+	if !ok {
+		return Path[K, W]{}, errVertexNotExists
+	}
+	return t.path(t.nearestNeighbour(s)), nil
+}
+
+// Calculate a tour by Christofides algorithm, the length of the tour is at most 3/2 of the optimal one
+// since the odd vertexes of the spanning tree are matched optimally.
+// g should be an undirected graph.
+func TSPChristofides[K comparable, W number](g Graph[K, W]) (Path[K, W], error) {
+	if g != nil && g.IsDigraph() {
+		return Path[K, W]{}, errDigraph
+	}
+	t, err := newTSPInstance(g)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	tour, err := t.christofides()
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	return t.path(tour), nil
+}
+
+// Improve a tour by 2-opt and Or-opt local search, tour should be a closed walk which visits every vertex
+// (e.g. the result of other TSP functions). The search stops at a local optimum or when the time budget is used up,
+// budget <= 0 means no time limit.
+func TSPLocalSearch[K comparable, W number](g Graph[K, W], tour Path[K, W], budget time.Duration) (Path[K, W], error) {
+	var deadline time.Time
+	if budget > 0 {
+		deadline = time.Now().Add(budget)
+	}
+	t, err := newTSPInstance(g)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	vs, err := t.tour(tour)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	t.improve(vs, deadline)
+	return t.path(vs), nil
+}
+
+// Solve the traveling salesman problem, small graphs are solved exactly by Held-Karp algorithm,
+// otherwise the tour built by Christofides algorithm (nearest neighbour heuristic for digraph)
+// is improved by local search within the time budget (budget <= 0 means no time limit).
+func TSP[K comparable, W number](g Graph[K, W], budget time.Duration) (Path[K, W], error) {
+	var deadline time.Time
+	if budget > 0 {
+		deadline = time.Now().Add(budget)
+	}
+	t, err := newTSPInstance(g)
+	if err != nil {
+		return Path[K, W]{}, err
+	}
+	if t.n <= tspAutoExactLimit {
+		return t.path(t.heldKarp()), nil
+	}
+	var tour []int
+	if t.ig.digraph {
+		tour = t.nearestNeighbour(0)
+	} else if tour, err = t.christofides(); err != nil {
+		return Path[K, W]{}, err
+	}
+	t.improve(tour, deadline)
+	return t.path(tour), nil
+}
@@ -0,0 +1,151 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+// a complete graph of random points in the plane, the weight is the rounded euclidean distance.
+func testEuclidean(r *rand.Rand, n int) Graph[int, int] {
+	xs, ys := make([]float64, n), make([]float64, n)
+	for i := 0; i < n; i++ {
+		xs[i], ys[i] = r.Float64()*1000, r.Float64()*1000
+	}
+	var es [][3]int
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			es = append(es, [3]int{i, j, int(math.Round(math.Hypot(xs[i]-xs[j], ys[i]-ys[j])))})
+		}
+	}
+	return testMultigraph(false, n, es)
+}
+
+// bruteForceTSP enumerates all the permutations which start from vertex 0.
+func bruteForceTSP(t *tspInstance[int, int]) float64 {
+	perm := make([]int, t.n)
+	for i := range perm {
+		perm[i] = i
+	}
+	best := math.Inf(1)
+	var rec func(k int)
+	rec = func(k int) {
+		if k == t.n {
+			best = math.Min(best, t.cost(perm))
+			return
+		}
+		for i := k; i < t.n; i++ {
+			perm[k], perm[i] = perm[i], perm[k]
+			rec(k + 1)
+			perm[k], perm[i] = perm[i], perm[k]
+		}
+	}
+	rec(1)
+	return best
+}
+
+func checkTour(g Graph[int, int], p Path[int, int]) {
+	checkTrail(g, p)
+	visited := map[int]bool{p.Source: true}
+	for _, k := range p.Edges {
+		e, _ := g.GetEdgeByKey(k)
+		visited[e.Head], visited[e.Tail] = true, true
+	}
+	if p.Source != p.Target || len(visited) != g.Order() {
+		panic(fmt.Sprintf("wrong tour %+v", p))
+	}
+}
+
+func TestTSP(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for round := 0; round < 5; round++ {
+		g := testEuclidean(r, 8)
+		ins, err := newTSPInstance(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		opt := bruteForceTSP(ins)
+		p, err := TSPHeldKarp(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		checkTour(g, p)
+		if float64(p.Weight) != opt {
+			panic(fmt.Sprintf("held-karp tour %d is not optimal %v", p.Weight, opt))
+		}
+		c, err := TSPChristofides(g)
+		if err != nil {
+			panic(err.Error())
+		}
+		checkTour(g, c)
+		if float64(c.Weight) > 1.5*opt {
+			panic(fmt.Sprintf("christofides tour %d exceeds 3/2 of %v", c.Weight, opt))
+		}
+		nn, err := TSPNearestNeighbour(g, 3)
+		if err != nil {
+			panic(err.Error())
+		}
+		checkTour(g, nn)
+		ls, err := TSPLocalSearch(g, nn, 0)
+		if err != nil {
+			panic(err.Error())
+		}
+		checkTour(g, ls)
+		if ls.Weight > nn.Weight || float64(ls.Weight) < opt {
+			panic(fmt.Sprintf("wrong local search result %d, start %d, optimal %v", ls.Weight, nn.Weight, opt))
+		}
+	}
+	// asymmetric distances.
+	d := testMultigraph(true, 5, [][3]int{{0, 1, 1}, {1, 2, 1}, {2, 3, 1}, {3, 4, 1}, {4, 0, 1}, {0, 4, 1}, {4, 3, 1}, {3, 2, 10}, {2, 1, 10}, {1, 0, 10}, {0, 2, 1}})
+	p, err := TSPHeldKarp(d)
+	if err != nil {
+		panic(err.Error())
+	}
+	checkTour(d, p)
+	if p.Weight != 5 {
+		panic(fmt.Sprintf("wrong asymmetric tour %+v", p))
+	}
+	if p, err = TSP(d, time.Second); err != nil || p.Weight != 5 {
+		panic(fmt.Sprintf("wrong asymmetric tour %+v, %v", p, err))
+	}
+	// a path graph is not complete, the tour walks every edge twice.
+	g := testMultigraph(false, 4, [][3]int{{0, 1, 1}, {1, 2, 2}, {2, 3, 3}})
+	if p, err = TSPChristofides(g); err != nil || p.Weight != 12 {
+		panic(fmt.Sprintf("wrong tour on path graph %+v, %v", p, err))
+	}
+	checkTour(g, p)
+	g = testEuclidean(r, 40)
+	c, err := TSPChristofides(g)
+	if err != nil {
+		panic(err.Error())
+	}
+	if p, err = TSP(g, time.Second); err != nil {
+		panic(err.Error())
+	}
+	checkTour(g, p)
+	if p.Weight > c.Weight {
+		panic(fmt.Sprintf("local search should not be worse than christofides, %d > %d", p.Weight, c.Weight))
+	}
+	if _, err = TSPHeldKarp(g); err != errTooManyVertexes {
+		panic(fmt.Sprintf("too many vertexes for held-karp, %v", err))
+	}
+	fmt.Println("=======> test tsp pass")
+}
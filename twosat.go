@@ -0,0 +1,171 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+// Literal is a boolean variable or its negation.
+type Literal[T comparable] struct {
+	Variable T
+	Negative bool
+}
+
+// The positive literal of variable v.
+func Pos[T comparable](v T) Literal[T] {
+	return Literal[T]{Variable: v}
+}
+
+// The negative literal of variable v.
+func Neg[T comparable](v T) Literal[T] {
+	return Literal[T]{Variable: v, Negative: true}
+}
+
+// The negation of literal l.
+func (l Literal[T]) Not() Literal[T] {
+	return Literal[T]{Variable: l.Variable, Negative: !l.Negative}
+}
+
+// TwoSAT is a solver of 2-satisfiability problem, i.e. the satisfiability of a conjunction of clauses
+// which are disjunctions of at most two literals.
+type TwoSAT[T comparable] struct {
+	idx     map[T]int
+	vars    []T
+	clauses [][2]Literal[T]
+}
+
+// Create a 2-SAT solver.
+func NewTwoSAT[T comparable]() *TwoSAT[T] {
+	return &TwoSAT[T]{idx: make(map[T]int)}
+}
+
+// Add a variable, the variables of clauses are added automatically,
+// a variable which appears in no clause can be assigned arbitrarily (false).
+func (s *TwoSAT[T]) AddVariable(v T) {
+	if _, ok := s.idx[v]; !ok {
+		s.idx[v] = len(s.vars)
+		s.vars = append(s.vars, v)
+	}
+}
+
+// Add clause (a or b).
+func (s *TwoSAT[T]) AddClause(a, b Literal[T]) {
+	s.AddVariable(a.Variable)
+	s.AddVariable(b.Variable)
+	s.clauses = append(s.clauses, [2]Literal[T]{a, b})
+}
+
+// Add clause (a), i.e. a must be true.
+func (s *TwoSAT[T]) AddUnit(a Literal[T]) {
+	s.AddClause(a, a)
+}
+
+// Add implication (a -> b), i.e. clause (not a or b).
+func (s *TwoSAT[T]) AddImplication(a, b Literal[T]) {
+	s.AddClause(a.Not(), b)
+}
+
+// Add constraint (a xor b), i.e. exactly one of a and b is true.
+func (s *TwoSAT[T]) AddExclusive(a, b Literal[T]) {
+	s.AddClause(a, b)
+	s.AddClause(a.Not(), b.Not())
+}
+
+// Add constraint (a <-> b), i.e. a and b have the same value.
+func (s *TwoSAT[T]) AddEquivalence(a, b Literal[T]) {
+	s.AddImplication(a, b)
+	s.AddImplication(b, a)
+}
+
+// The number of variables.
+func (s *TwoSAT[T]) Variables() int {
+	return len(s.vars)
+}
+
+// The number of clauses.
+func (s *TwoSAT[T]) Clauses() int {
+	return len(s.clauses)
+}
+
+// the vertex of literal l in the implication graph, the literals of variable i are 2i and 2i+1 (negative).
+func (s *TwoSAT[T]) vertex(l Literal[T]) int {
+	v := 2 * s.idx[l.Variable]
+	if l.Negative {
+		v++
+	}
+	return v
+}
+
+// Build the implication graph, every clause (a or b) is replaced with two arcs (not a -> b) and (not b -> a).
+// The vertexes of variable i are 2i (positive literal) and 2i+1 (negative literal), and the value of every vertex is its literal.
+func (s *TwoSAT[T]) ImplicationGraph() (Digraph[int, int], error) {
+	g := NewDigraph[int, int]("implication")
+	for i, v := range s.vars {
+		if err := g.AddVertex(Vertex[int, int]{Key: 2 * i, Value: Pos(v)}); err != nil {
+			return nil, err
+		}
+		if err := g.AddVertex(Vertex[int, int]{Key: 2*i + 1, Value: Neg(v)}); err != nil {
+			return nil, err
+		}
+	}
+	var ek int
+	for _, c := range s.clauses {
+		a, b := s.vertex(c[0]), s.vertex(c[1])
+		if err := g.AddEdge(Edge[int, int]{Key: ek, Tail: a ^ 1, Head: b}); err != nil {
+			return nil, err
+		}
+		ek++
+		if a == b {
+			continue
+		}
+		if err := g.AddEdge(Edge[int, int]{Key: ek, Tail: b ^ 1, Head: a}); err != nil {
+			return nil, err
+		}
+		ek++
+	}
+	return g, nil
+}
+
+/*
+Solve the 2-SAT problem, return an assignment of variables if the clauses are satisfiable.
+
+The clauses are unsatisfiable if and only if some variable x and its negation are in the same strongly connected component
+of the implication graph. Otherwise, Tarjan's algorithm finds the components in reverse topological order,
+and assign x = true if the component of x comes before (in reverse topological order) the component of not x,
+so no true literal implies a false literal.
+*/
+func (s *TwoSAT[T]) Solve() (map[T]bool, bool, error) {
+	g, err := s.ImplicationGraph()
+	if err != nil {
+		return nil, false, err
+	}
+	cs, _, err := StronglyConnectedComponentTarjan(g, false)
+	if err != nil {
+		return nil, false, err
+	}
+	comp := make([]int, 2*len(s.vars))
+	for i, c := range cs {
+		for _, v := range c {
+			comp[v] = i
+		}
+	}
+	res := make(map[T]bool)
+	for i, v := range s.vars {
+		if comp[2*i] == comp[2*i+1] {
+			return nil, false, nil
+		}
+		res[v] = comp[2*i] < comp[2*i+1]
+	}
+	return res, true, nil
+}
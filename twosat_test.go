@@ -0,0 +1,76 @@
+/*
+	Copyright (C) 2023 flxj(https://github.com/flxj)
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package graphlib
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestTwoSAT(t *testing.T) {
+	s := NewTwoSAT[string]()
+	s.AddClause(Pos("a"), Pos("b"))
+	s.AddImplication(Pos("a"), Pos("b"))
+	s.AddExclusive(Pos("b"), Pos("c"))
+	res, ok, err := s.Solve()
+	if err != nil {
+		panic(err.Error())
+	}
+	if !ok || !res["b"] || res["c"] {
+		panic(fmt.Sprintf("wrong assignment %v", res))
+	}
+	s.AddUnit(Neg("b"))
+	if _, ok, _ = s.Solve(); ok {
+		panic("clauses should be unsatisfiable")
+	}
+
+	// compare with brute force.
+	const n = 5
+	for round := 0; round < 200; round++ {
+		s := NewTwoSAT[int]()
+		var cs [][2]Literal[int]
+		for i := 0; i < 8; i++ {
+			a := Literal[int]{Variable: rand.Intn(n), Negative: rand.Intn(2) == 0}
+			b := Literal[int]{Variable: rand.Intn(n), Negative: rand.Intn(2) == 0}
+			s.AddClause(a, b)
+			cs = append(cs, [2]Literal[int]{a, b})
+		}
+		satisfied := func(value func(int) bool) bool {
+			for _, c := range cs {
+				if value(c[0].Variable) == c[0].Negative && value(c[1].Variable) == c[1].Negative {
+					return false
+				}
+			}
+			return true
+		}
+		var expect bool
+		for m := 0; m < 1<<n && !expect; m++ {
+			expect = satisfied(func(v int) bool { return m&(1<<v) != 0 })
+		}
+		res, ok, err := s.Solve()
+		if err != nil {
+			panic(err.Error())
+		}
+		if ok != expect {
+			panic(fmt.Sprintf("satisfiability of %v should be %v", cs, expect))
+		}
+		if ok && !satisfied(func(v int) bool { return res[v] }) {
+			panic(fmt.Sprintf("assignment %v does not satisfy %v", res, cs))
+		}
+	}
+}
@@ -454,3 +454,65 @@ func (f *FIFO[T]) Back() (k T, ok bool) {
 func (f *FIFO[T]) Clean() {
 	f.head, f.tail = 0, 0
 }
+
+type arc struct {
+	to     int     // index of the other endpoint
+	edge   int     // index of the edge
+	weight float64 // weight of the edge
+}
+
+// indexedGraph is an int indexed snapshot of a graph,
+// it's used by algorithms which scan the adjacency of vertexes many times.
+// For a digraph out[v] records the arcs leaving v and in[v] records the arcs entering v,
+// for an undirected graph in and out are the same, and a loop is recorded only once.
+type indexedGraph[K comparable, W number] struct {
+	digraph bool
+	keys    []K
+	idx     map[K]int
+	edges   []Edge[K, W]
+	out     [][]arc
+	in      [][]arc
+}
+
+func newIndexedGraph[K comparable, W number](g Graph[K, W]) *indexedGraph[K, W] {
+	vs := g.AllVertexes()
+	ig := &indexedGraph[K, W]{
+		digraph: g.IsDigraph(),
+		keys:    make([]K, len(vs)),
+		idx:     make(map[K]int),
+		edges:   g.AllEdges(),
+		out:     make([][]arc, len(vs)),
+	}
+	for i, v := range vs {
+		ig.keys[i] = v.Key
+		ig.idx[v.Key] = i
+	}
+	if ig.digraph {
+		ig.in = make([][]arc, len(vs))
+	} else {
+		ig.in = ig.out
+	}
+	for i, e := range ig.edges {
+		t, h, w := ig.idx[e.Tail], ig.idx[e.Head], float64(e.Weight)
+		ig.out[t] = append(ig.out[t], arc{to: h, edge: i, weight: w})
+		if ig.digraph {
+			ig.in[h] = append(ig.in[h], arc{to: t, edge: i, weight: w})
+		} else if t != h {
+			ig.out[h] = append(ig.out[h], arc{to: t, edge: i, weight: w})
+		}
+	}
+	return ig
+}
+
+func (g *indexedGraph[K, W]) order() int {
+	return len(g.keys)
+}
+
+func (g *indexedGraph[K, W]) hasNegativeWeight() bool {
+	for _, e := range g.edges {
+		if e.Weight < 0 {
+			return true
+		}
+	}
+	return false
+}